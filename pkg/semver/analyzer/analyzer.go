@@ -0,0 +1,794 @@
+// Package analyzer analyzes the history of a git repository to generate
+// versions and changelogs.
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/indece-official/semantic-version/pkg/semver"
+	"github.com/indece-official/semantic-version/pkg/semver/changelog"
+	"github.com/indece-official/semantic-version/pkg/semver/config"
+)
+
+// ErrNoBranchConfig is returned if no branch config matches the current branch
+var ErrNoBranchConfig = errors.New("no branch config found for current branch")
+
+// Options contains optional settings of an analyzer
+type Options struct {
+	// Branch overrides the branch name detected from HEAD
+	Branch string
+	// Build overrides the build number of generated versions
+	Build *int
+	// Date overrides the date of calendar versions, default is the commit date
+	// of HEAD
+	Date *time.Time
+	// Logger receives the diagnostics of the analyzer, default is the logger
+	// set by semver.SetDefaultLogger
+	Logger semver.Logger
+	// Project is the name of the (monorepo) project to analyze, the whole
+	// repository is analyzed if empty
+	Project string
+}
+
+// Analyzer analyzes a git repository
+type Analyzer struct {
+	repo    *git.Repository
+	cfg     *config.Config
+	options *Options
+	logger  semver.Logger
+	project *config.ProjectConfig
+	// commit-hash => semver.VersionInfo of tag
+	mapCommitTags map[string][]*semver.Tag
+	mapTags       map[string]bool
+	head          *plumbing.Reference
+	headCommit    *object.Commit
+	mailmap       *changelog.Mailmap
+	// identity key => hash of the first contribution (only loaded if the
+	// contributors section is enabled)
+	firstContributions map[string]string
+}
+
+// Load loads the head and all tags matching a version pattern
+func (a *Analyzer) Load() error {
+	var err error
+
+	if a.options.Project != "" {
+		a.project = a.cfg.GetProject(a.options.Project)
+		if a.project == nil {
+			return fmt.Errorf("unknown project \"%s\"", a.options.Project)
+		}
+	}
+
+	a.head, err = a.repo.Head()
+	if err != nil {
+		return fmt.Errorf("can't load head: %s", err)
+	}
+
+	a.logger.Debugf("Head is %s", a.head.Hash().String())
+
+	a.headCommit, err = a.repo.CommitObject(a.head.Hash())
+	if err != nil {
+		return fmt.Errorf("can't load head commit: %s", err)
+	}
+
+	a.logger.Debugf("Head commit is %s", a.headCommit.Hash.String())
+
+	tags, err := a.repo.Tags()
+	if err != nil {
+		return fmt.Errorf("can't load tags: %s", err)
+	}
+
+	for {
+		tag, err := tags.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return fmt.Errorf("can't iterate tags: %s", err)
+		}
+
+		tagName := tag.Name().Short()
+		revision := plumbing.Revision(tagName)
+		tagCommit, err := a.repo.ResolveRevision(revision)
+		if err != nil {
+			return fmt.Errorf("can't resolve tag %s: %s", tagName, err)
+		}
+		tagCommitStr := tagCommit.String()
+
+		for _, branchConfig := range a.getBranchConfigs() {
+			versionInfo := branchConfig.GetVersionPattern().Parse(tagName)
+			if versionInfo == nil {
+				continue
+			}
+
+			a.logger.Debugf("Found tag %s (%s) => %v", tagName, tagCommitStr, versionInfo)
+
+			if _, exists := a.mapCommitTags[tagCommitStr]; !exists {
+				a.mapCommitTags[tagCommitStr] = []*semver.Tag{}
+			}
+
+			tag := semver.NewTag(tagName, versionInfo, tagCommitStr)
+
+			a.mapCommitTags[tagCommitStr] = append(a.mapCommitTags[tagCommitStr], tag)
+			a.mapTags[tagName] = true
+		}
+	}
+
+	err = a.loadMailmap()
+	if err != nil {
+		return err
+	}
+
+	if a.cfg.Changelog.Contributors {
+		err = a.loadFirstContributions()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadMailmap loads the configured mailmap file, a missing default mailmap
+// file is ignored
+func (a *Analyzer) loadMailmap() error {
+	var err error
+
+	filename := a.cfg.Changelog.Mailmap
+	if filename == "" {
+		filename = config.DefaultMailmapFile
+
+		if _, err := os.Stat(filename); os.IsNotExist(err) {
+			return nil
+		}
+	}
+
+	a.mailmap, err = changelog.LoadMailmap(filename)
+	if err != nil {
+		return err
+	}
+
+	a.logger.Debugf("Loaded mailmap %s", filename)
+
+	return nil
+}
+
+// loadFirstContributions finds the first commit of each contributor in the
+// history of HEAD
+func (a *Analyzer) loadFirstContributions() error {
+	commits := []*object.Commit{}
+
+	commitIter := object.NewCommitIterBSF(a.headCommit, map[plumbing.Hash]bool{}, []plumbing.Hash{})
+	err := commitIter.ForEach(func(commit *object.Commit) error {
+		commits = append(commits, commit)
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("can't load history: %s", err)
+	}
+
+	a.firstContributions = changelog.GetFirstContributions(commits, a.mailmap)
+
+	a.logger.Debugf("Found %d contributors in %d commits", len(a.firstContributions), len(commits))
+
+	return nil
+}
+
+// newCommitParser creates a commit parser for the configured commit types
+// logging to the analyzer's logger
+func (a *Analyzer) newCommitParser() *changelog.CommitParser {
+	commitParser := changelog.NewCommitParser(a.cfg.CommitTypes)
+	commitParser.SetIssuePatterns(a.cfg.IssuePatterns)
+	commitParser.SetMailmap(a.mailmap)
+	commitParser.SetScopes(a.cfg.Changelog.Scopes, a.cfg.Changelog.HiddenScopes)
+	commitParser.SetGroupByScope(a.cfg.Changelog.GroupByScope)
+	commitParser.SetContributors(a.cfg.Changelog.Contributors, a.firstContributions)
+	commitParser.SetLogger(a.logger)
+
+	return commitParser
+}
+
+// GetProject returns the config of the analyzed project, nil if the whole
+// repository is analyzed
+func (a *Analyzer) GetProject() *config.ProjectConfig {
+	return a.project
+}
+
+func (a *Analyzer) getBranchConfigs() []*config.BranchConfig {
+	if a.project != nil {
+		return a.project.GetBranches()
+	}
+
+	return a.cfg.Branches
+}
+
+// touchesProject checks if the commit changes files below the project's path
+func (a *Analyzer) touchesProject(commit *object.Commit) (bool, error) {
+	if a.project == nil || a.project.GetPath() == "" {
+		return true, nil
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return false, fmt.Errorf("can't load tree of commit %s: %s", commit.Hash.String(), err)
+	}
+
+	var parentTree *object.Tree
+
+	if commit.NumParents() > 0 {
+		parent, err := commit.Parent(0)
+		if err != nil {
+			return false, fmt.Errorf("can't load parent of commit %s: %s", commit.Hash.String(), err)
+		}
+
+		parentTree, err = parent.Tree()
+		if err != nil {
+			return false, fmt.Errorf("can't load tree of commit %s: %s", parent.Hash.String(), err)
+		}
+	}
+
+	changes, err := object.DiffTree(parentTree, tree)
+	if err != nil {
+		return false, fmt.Errorf("can't diff commit %s: %s", commit.Hash.String(), err)
+	}
+
+	for _, change := range changes {
+		if a.project.ContainsFile(change.From.Name) || a.project.ContainsFile(change.To.Name) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// filterCommits returns all commits changing files of the analyzed project
+func (a *Analyzer) filterCommits(commits []*object.Commit) ([]*object.Commit, error) {
+	if a.project == nil {
+		return commits, nil
+	}
+
+	filteredCommits := []*object.Commit{}
+
+	for _, commit := range commits {
+		touches, err := a.touchesProject(commit)
+		if err != nil {
+			return nil, err
+		}
+
+		if !touches {
+			a.logger.Debugf("Skipping commit %s not changing project %s", commit.Hash.String(), a.project.Name)
+
+			continue
+		}
+
+		filteredCommits = append(filteredCommits, commit)
+	}
+
+	return filteredCommits, nil
+}
+
+// GetCurrentBranchConfig returns the name and config of the current branch
+//
+// If no config matches the branch, the returned config is nil.
+func (a *Analyzer) GetCurrentBranchConfig() (string, *config.BranchConfig, error) {
+	branchName := ""
+
+	if a.options.Branch != "" {
+		branchName = a.options.Branch
+	} else {
+		branchName = a.head.Name().Short()
+	}
+
+	if branchName == "" || branchName == "HEAD" {
+		a.logger.Debugf("Found no valid branch name: %s", branchName)
+
+		return "", nil, nil
+	}
+
+	for _, branchConfig := range a.getBranchConfigs() {
+		if branchConfig.GetBranchPattern().Match(branchName) {
+			a.logger.Debugf("Found config %s for branch name %s", branchConfig.BranchPattern, branchName)
+
+			return branchName, branchConfig, nil
+		}
+	}
+
+	a.logger.Debugf("Found no config for branch name %s", branchName)
+
+	return branchName, nil, nil
+}
+
+// GetHighestFinalReleaseTag returns the tag of the final release selected by
+// the configured strategy, nil if no final release exists
+func (a *Analyzer) GetHighestFinalReleaseTag() (*semver.Tag, error) {
+	var highestTag *semver.Tag
+
+	// Get relevant tags
+	finalReleaseTags := []*semver.Tag{}
+	for _, tags := range a.mapCommitTags {
+		for _, tag := range tags {
+			if a.cfg.GetReleaseChannels().IsFinal(tag.Version.ReleaseChannel) {
+				finalReleaseTags = append(finalReleaseTags, tag)
+
+				break
+			}
+		}
+	}
+
+	// Sort ascending by version
+	sort.Slice(finalReleaseTags, func(i, j int) bool {
+		if c := finalReleaseTags[i].Compare(finalReleaseTags[j]); c != 0 {
+			return c < 0
+		}
+
+		return finalReleaseTags[i].Name < finalReleaseTags[j].Name
+	})
+
+	// Build map of highest versions for commits
+	commitHighestTagMap := map[string]*semver.Tag{}
+	for _, tag := range finalReleaseTags {
+		a.logger.Debugf("Processing tag %s ...", tag.Name)
+		revision := plumbing.Revision(tag.Name)
+		tagHash, err := a.repo.ResolveRevision(revision)
+		if err != nil || tagHash == nil {
+			return nil, fmt.Errorf("can't resolve tag %s: %s", tag.Name, err)
+		}
+
+		tagCommit, err := a.repo.CommitObject(*tagHash)
+		if err != nil {
+			return nil, fmt.Errorf("can't resolve tag hash %s: %s", tag.Name, err)
+		}
+
+		commitIter := object.NewCommitIterBSF(tagCommit, map[plumbing.Hash]bool{}, []plumbing.Hash{})
+		commitIter.ForEach(func(commit *object.Commit) error {
+			commitHash := commit.Hash.String()
+
+			switch a.cfg.Strategy {
+			case config.VersionStrategyLatest:
+				commitHighestTagMap[commitHash] = tag
+			case config.VersionStrategyOverallLatest:
+				commitHighestTagMap[commitHash] = tag
+			case config.VersionStrategyClosest:
+				if commitHighestTagMap[commitHash] == nil {
+					commitHighestTagMap[commitHash] = tag
+				}
+			}
+
+			return nil
+		})
+	}
+
+	commitIter := object.NewCommitPostorderIter(a.headCommit, []plumbing.Hash{})
+	finished := false
+	for !finished {
+		commit, err := commitIter.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return nil, fmt.Errorf("can't iterate commits: %s", err)
+		}
+
+		tag, exists := commitHighestTagMap[commit.Hash.String()]
+		if !exists {
+			continue
+		}
+
+		switch a.cfg.Strategy {
+		case config.VersionStrategyLatest:
+			highestTag = tag
+			finished = true
+		case config.VersionStrategyOverallLatest:
+			if highestTag == nil || tag.Compare(highestTag) > 0 {
+				highestTag = tag
+			}
+		case config.VersionStrategyClosest:
+			highestTag = tag
+			finished = true
+		}
+	}
+
+	if highestTag == nil {
+		a.logger.Debugf("Found no highest release tag")
+
+		return nil, nil
+	}
+
+	a.logger.Debugf("Found highest release tag %s", highestTag.Name)
+
+	return highestTag, nil
+}
+
+// GetHighestFinalReleaseVersion returns the version of the final release
+// selected by the configured strategy, nil if no final release exists
+func (a *Analyzer) GetHighestFinalReleaseVersion() (*semver.VersionInfo, error) {
+	highestTag, err := a.GetHighestFinalReleaseTag()
+	if err != nil || highestTag == nil {
+		return nil, err
+	}
+
+	return highestTag.Version, nil
+}
+
+// GetCommitsSinceLastRelease returns all commits since the last release with
+// a release channel of at least minReleaseChannel and the branch's channel
+func (a *Analyzer) GetCommitsSinceLastRelease(branchConfig *config.BranchConfig, minReleaseChannel semver.ReleaseChannel) ([]*object.Commit, error) {
+	releaseChannels := a.cfg.GetReleaseChannels()
+
+	commits := []*object.Commit{}
+
+	seenExternal := map[plumbing.Hash]bool{}
+	for commitHash, tags := range a.mapCommitTags {
+		for _, tag := range tags {
+			versionInfo := tag.Version
+
+			if !releaseChannels.IsRelease(versionInfo.ReleaseChannel) || releaseChannels.GetPrio(versionInfo.ReleaseChannel) < releaseChannels.GetPrio(minReleaseChannel) {
+				continue
+			}
+
+			if releaseChannels.GetPrio(versionInfo.ReleaseChannel) >= releaseChannels.GetPrio(branchConfig.ReleaseChannel) {
+				// Found matching release commit
+				commit, err := a.repo.CommitObject(plumbing.NewHash(commitHash))
+				if err != nil {
+					return nil, fmt.Errorf("can't load commit object: %s", err)
+				}
+
+				commitIter := object.NewCommitPreorderIter(commit, seenExternal, []plumbing.Hash{})
+				commitIter.ForEach(func(c *object.Commit) error {
+					seenExternal[c.Hash] = true
+
+					return nil
+				})
+
+				seenExternal[plumbing.NewHash(commitHash)] = true
+
+				break
+			}
+		}
+	}
+
+	commitIter := object.NewCommitIterBSF(a.headCommit, seenExternal, []plumbing.Hash{})
+	for {
+		commit, err := commitIter.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return nil, fmt.Errorf("can't iterate commits: %s", err)
+		}
+
+		a.logger.Debugf("Analyze commit %s for changelog => %v", commit.Hash.String(), a.mapCommitTags[commit.Hash.String()])
+
+		commits = append(commits, commit)
+	}
+
+	return a.filterCommits(commits)
+}
+
+// getDate returns the date of calendar versions
+func (a *Analyzer) getDate() time.Time {
+	if a.options.Date != nil {
+		return *a.options.Date
+	}
+
+	return a.headCommit.Committer.When
+}
+
+// applyCalendarVersion sets the date of calendar versions and resets the minor
+// and micro number (patch) if the date part changed since the previous release
+// previousVersion (nil for the first release), within the same period the
+// version is incremented like any other version
+func (a *Analyzer) applyCalendarVersion(branchConfig *config.BranchConfig, versionInfo *semver.VersionInfo, previousVersion *semver.VersionInfo) {
+	versionPattern := branchConfig.GetVersionPattern()
+	if !versionPattern.UsesDate() {
+		return
+	}
+
+	versionInfo.SetDate(a.getDate(), versionPattern.UsesWeek())
+
+	if previousVersion == nil || !versionPattern.IsSamePeriod(versionInfo, previousVersion) {
+		versionInfo.Minor = 0
+		versionInfo.Patch = 0
+	}
+
+	a.logger.Debugf("Calendar version %d-%d-%d (week %d), micro %d", versionInfo.Year, versionInfo.Month, versionInfo.Day, versionInfo.Week, versionInfo.Patch)
+}
+
+// getNextBuild returns the build number following the highest existing tag of
+// the version (same version, release channel and branch), 0 if no such tag
+// exists
+func (a *Analyzer) getNextBuild(branchConfig *config.BranchConfig, versionInfo *semver.VersionInfo) int {
+	versionPattern := branchConfig.GetVersionPattern()
+	nextBuild := 0
+
+	for _, tags := range a.mapCommitTags {
+		for _, tag := range tags {
+			if tag.Version.Build < nextBuild {
+				continue
+			}
+
+			// The tag belongs to the version if only the build number differs
+			versionInfoCopy := *versionInfo
+			versionInfoCopy.Build = tag.Version.Build
+			if versionPattern.Generate(&versionInfoCopy) != tag.Name {
+				continue
+			}
+
+			nextBuild = tag.Version.Build + 1
+		}
+	}
+
+	a.logger.Debugf("Next build number is %d", nextBuild)
+
+	return nextBuild
+}
+
+// GenerateVersionTag generates a unique version tag for the branch
+func (a *Analyzer) GenerateVersionTag(branchName string, branchConfig *config.BranchConfig, versionInfo *semver.VersionInfo) (string, error) {
+	versionInfo.Branch = branchName
+	versionInfo.ReleaseChannel = branchConfig.ReleaseChannel
+	versionInfo.Scheme = branchConfig.GetVersionScheme()
+	versionInfo.Commit = a.headCommit.Hash.String()
+	versionInfo.ShortCommit = a.headCommit.Hash.String()[:10]
+
+	if a.options.Build != nil {
+		versionInfo.Build = *a.options.Build
+	} else if branchConfig.BuildCounter == config.BuildCounterContinue {
+		versionInfo.Build = a.getNextBuild(branchConfig, versionInfo)
+	}
+
+	newTag, err := branchConfig.GetVersionPattern().GenerateUnique(versionInfo, a.mapTags, true)
+	if err != nil {
+		return "", err
+	}
+
+	return newTag, nil
+}
+
+// ComputeVersion computes the new version for the current branch including
+// all details of the computation
+//
+// ErrNoBranchConfig is returned if no branch config matches the current branch.
+func (a *Analyzer) ComputeVersion() (*VersionResult, error) {
+	return a.computeVersion(map[string]*VersionResult{})
+}
+
+// getDependencyResults computes the versions of all dependencies of the
+// analyzed project, computed versions are cached in results by project name
+//
+// Dependencies without a branch config for the current branch are skipped.
+func (a *Analyzer) getDependencyResults(results map[string]*VersionResult) ([]*VersionResult, error) {
+	dependencyResults := []*VersionResult{}
+
+	if a.project == nil {
+		return dependencyResults, nil
+	}
+
+	for _, dependency := range a.project.DependsOn {
+		result, exists := results[dependency]
+		if !exists {
+			dependencyAnalyzer := NewAnalyzer(a.repo, a.cfg, &Options{
+				Branch:  a.options.Branch,
+				Date:    a.options.Date,
+				Logger:  a.options.Logger,
+				Project: dependency,
+			})
+
+			err := dependencyAnalyzer.Load()
+			if err != nil {
+				return nil, fmt.Errorf("error loading dependency %s: %s", dependency, err)
+			}
+
+			result, err = dependencyAnalyzer.computeVersion(results)
+			if err != nil && !errors.Is(err, ErrNoBranchConfig) {
+				return nil, fmt.Errorf("error computing version of dependency %s: %s", dependency, err)
+			}
+
+			results[dependency] = result
+		}
+
+		if result != nil {
+			dependencyResults = append(dependencyResults, result)
+		}
+	}
+
+	return dependencyResults, nil
+}
+
+func (a *Analyzer) computeVersion(results map[string]*VersionResult) (*VersionResult, error) {
+	branchName, branchConfig, err := a.GetCurrentBranchConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error getting branch config: %s", err)
+	}
+
+	if branchConfig == nil {
+		return nil, ErrNoBranchConfig
+	}
+
+	highestTag, err := a.GetHighestFinalReleaseTag()
+	if err != nil {
+		return nil, fmt.Errorf("error getting highest final release: %s", err)
+	}
+
+	commits, err := a.GetCommitsSinceLastRelease(branchConfig, semver.ReleaseChannelFinal)
+	if err != nil {
+		return nil, fmt.Errorf("error loading commits since last release: %s", err)
+	}
+
+	commitParser := a.newCommitParser()
+	commitParser.Parse(commits)
+
+	dependencyResults, err := a.getDependencyResults(results)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &VersionResult{
+		branchConfig:   branchConfig,
+		Project:        a.options.Project,
+		Dependencies:   []*VersionResultDependency{},
+		Branch:         branchName,
+		BranchPattern:  branchConfig.BranchPattern,
+		VersionPattern: branchConfig.VersionPattern,
+		ReleaseChannel: branchConfig.ReleaseChannel,
+		Increment:      semver.VersionIncrementLevelNone,
+		Reasons:        []*VersionResultReason{},
+	}
+
+	var versionInfo *semver.VersionInfo
+
+	if highestTag != nil {
+		// Copy the version, so the loaded tag stays untouched
+		versionInfoCopy := *highestTag.Version
+		versionInfo = &versionInfoCopy
+
+		versionIncrement := commitParser.GetVersionIncrement()
+
+		for _, dependencyResult := range dependencyResults {
+			level := a.project.GetDependencyIncrement(dependencyResult.Increment)
+			if level == semver.VersionIncrementLevelNone {
+				continue
+			}
+
+			a.logger.Debugf("Dependency %s (%s) causes %s increment", dependencyResult.Project, dependencyResult.Increment, level)
+
+			versionIncrement.Increment(level)
+			result.Dependencies = append(result.Dependencies, newVersionResultDependency(dependencyResult, level))
+		}
+
+		if versionIncrement.GetLevel() == semver.VersionIncrementLevelNone && !a.cfg.GetReleaseChannels().IsFinal(branchConfig.ReleaseChannel) {
+			// Prereleases are derived from the final release, so they always
+			// need a new build
+			versionIncrement.IncrementBuild()
+		}
+
+		if branchConfig.GetVersionPattern().UsesDate() {
+			// Calendar versions only increment the numbers of their pattern
+			versionIncrement.Limit(branchConfig.GetVersionPattern().GetMaxIncrementLevel())
+		}
+
+		versionIncrement.Apply(versionInfo)
+
+		if versionIncrement.GetLevel() != semver.VersionIncrementLevelNone {
+			a.applyCalendarVersion(branchConfig, versionInfo, highestTag.Version)
+		}
+
+		result.PreviousVersion = highestTag.Name
+		result.Increment = versionIncrement.GetLevel()
+
+		for _, parsedCommit := range commitParser.GetVersionIncrementReasons() {
+			result.Reasons = append(result.Reasons, newVersionResultReason(parsedCommit))
+		}
+	} else {
+		versionInfo = &semver.VersionInfo{
+			Major:           1,
+			Minor:           0,
+			Patch:           0,
+			Build:           0,
+			ReleaseChannels: a.cfg.GetReleaseChannels(),
+		}
+
+		a.applyCalendarVersion(branchConfig, versionInfo, nil)
+	}
+
+	result.Version, err = a.GenerateVersionTag(branchName, branchConfig, versionInfo)
+	if err != nil {
+		return nil, fmt.Errorf("error generating version: %s", err)
+	}
+
+	a.logger.Infof("Computed version %s (%s increment from %s)", result.Version, result.Increment, result.PreviousVersion)
+
+	result.versionInfo = versionInfo
+	result.Major = versionInfo.Major
+	result.Minor = versionInfo.Minor
+	result.Patch = versionInfo.Patch
+	result.Build = versionInfo.Build
+	result.Commit = versionInfo.Commit
+	result.ShortCommit = versionInfo.ShortCommit
+
+	return result, nil
+}
+
+// RegenerateVersion generates a new version tag for a result of ComputeVersion
+// which is neither used by a local tag nor contained in usedTags (e.g. tags
+// existing on a remote)
+func (a *Analyzer) RegenerateVersion(result *VersionResult, usedTags map[string]bool) error {
+	allUsedTags := map[string]bool{}
+	for tagName, used := range a.mapTags {
+		allUsedTags[tagName] = used
+	}
+	for tagName, used := range usedTags {
+		allUsedTags[tagName] = allUsedTags[tagName] || used
+	}
+
+	newTag, err := result.branchConfig.GetVersionPattern().GenerateUnique(result.versionInfo, allUsedTags, false)
+	if err != nil {
+		return fmt.Errorf("error generating version: %s", err)
+	}
+
+	result.Version = newTag
+	result.Build = result.versionInfo.Build
+
+	return nil
+}
+
+// GetVersion computes the new version tag for the current branch
+//
+// ErrNoBranchConfig is returned if no branch config matches the current branch.
+func (a *Analyzer) GetVersion() (string, error) {
+	result, err := a.ComputeVersion()
+	if err != nil {
+		return "", err
+	}
+
+	return result.Version, nil
+}
+
+// GetChangelog generates the changelog with all changes since the last release
+//
+// ErrNoBranchConfig is returned if no branch config matches the current branch.
+func (a *Analyzer) GetChangelog() (string, error) {
+	result, err := a.ComputeVersion()
+	if err != nil {
+		return "", err
+	}
+
+	commits, err := a.GetCommitsSinceLastRelease(result.branchConfig, a.cfg.GetReleaseChannels().GetLowestRelease())
+	if err != nil {
+		return "", fmt.Errorf("error loading commits since last release: %s", err)
+	}
+
+	commitParser := a.newCommitParser()
+	commitParser.Parse(commits)
+
+	return a.renderChangelog(commitParser, 1, result.Version, result.PreviousVersion, result.versionInfo, time.Now())
+}
+
+// NewAnalyzer creates a new analyzer for the repository, Load must be called
+// before using it
+func NewAnalyzer(repo *git.Repository, cfg *config.Config, options *Options) *Analyzer {
+	if options == nil {
+		options = &Options{}
+	}
+
+	return &Analyzer{
+		repo:          repo,
+		cfg:           cfg,
+		options:       options,
+		logger:        semver.GetLogger(options.Logger),
+		mapCommitTags: map[string][]*semver.Tag{},
+		mapTags:       map[string]bool{},
+	}
+}
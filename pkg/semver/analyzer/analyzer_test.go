@@ -0,0 +1,957 @@
+package analyzer
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/indece-official/semantic-version/pkg/semver"
+	"github.com/indece-official/semantic-version/pkg/semver/changelog"
+	"github.com/indece-official/semantic-version/pkg/semver/config"
+	"github.com/stretchr/testify/assert"
+)
+
+type testRepo struct {
+	t        *testing.T
+	repo     *git.Repository
+	worktree *git.Worktree
+	count    int
+}
+
+func newTestRepo(t *testing.T) *testRepo {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	assert.NoError(t, err)
+
+	worktree, err := repo.Worktree()
+	assert.NoError(t, err)
+
+	return &testRepo{
+		t:        t,
+		repo:     repo,
+		worktree: worktree,
+	}
+}
+
+func (r *testRepo) commit(message string) plumbing.Hash {
+	return r.commitFile("testfile.txt", message)
+}
+
+func (r *testRepo) commitFile(filename string, message string) plumbing.Hash {
+	return r.commitFileAs(filename, "Test", "test@example.com", message)
+}
+
+func (r *testRepo) commitAs(name string, email string, message string) plumbing.Hash {
+	return r.commitFileAs("testfile.txt", name, email, message)
+}
+
+func (r *testRepo) commitFileAs(filename string, name string, email string, message string) plumbing.Hash {
+	r.count++
+
+	file, err := r.worktree.Filesystem.Create(filename)
+	assert.NoError(r.t, err)
+	_, err = file.Write([]byte(message))
+	assert.NoError(r.t, err)
+	assert.NoError(r.t, file.Close())
+
+	_, err = r.worktree.Add(filename)
+	assert.NoError(r.t, err)
+
+	hash, err := r.worktree.Commit(message, &git.CommitOptions{
+		Author: &object.Signature{
+			Name:  name,
+			Email: email,
+			When:  time.Date(2021, 1, 1, 0, 0, r.count, 0, time.UTC),
+		},
+	})
+	assert.NoError(r.t, err)
+
+	return hash
+}
+
+func (r *testRepo) tag(name string, hash plumbing.Hash) {
+	_, err := r.repo.CreateTag(name, hash, nil)
+	assert.NoError(r.t, err)
+}
+
+func (r *testRepo) checkout(branch string, create bool) {
+	err := r.worktree.Checkout(&git.CheckoutOptions{
+		Branch: plumbing.NewBranchReferenceName(branch),
+		Create: create,
+	})
+	assert.NoError(r.t, err)
+}
+
+func newTestAnalyzer(t *testing.T, r *testRepo, options *Options) *Analyzer {
+	err := config.DefaultConfig.Parse()
+	assert.NoError(t, err)
+
+	a := NewAnalyzer(r.repo, config.DefaultConfig, options)
+
+	err = a.Load()
+	assert.NoError(t, err)
+
+	return a
+}
+
+func TestAnalyzerGetVersion(t *testing.T) {
+	r := newTestRepo(t)
+	hash := r.commit("Initial commit")
+	r.tag("v1.0.0", hash)
+	r.commit("feat: Some change")
+
+	a := newTestAnalyzer(t, r, nil)
+
+	version, err := a.GetVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.1.0", version)
+
+	changelog, err := a.GetChangelog()
+	assert.NoError(t, err)
+	assert.Contains(t, changelog, "* Some change (")
+}
+
+func TestAnalyzerIncrementNone(t *testing.T) {
+	r := newTestRepo(t)
+	r.tag("v1.0.0", r.commit("Initial commit"))
+	hash := r.commit("deps: Updated dependencies")
+
+	cfg := &config.Config{
+		Strategy: config.VersionStrategyLatest,
+		CommitTypes: []*changelog.CommitType{
+			{Type: "fix", Increment: "PATCH", ChangelogHeading: "Fixes"},
+			{Type: "deps", Increment: "NONE"},
+		},
+		Branches: []*config.BranchConfig{
+			{BranchPattern: "master", ReleaseChannel: semver.ReleaseChannelFinal, VersionPattern: "v{major}.{minor}.{patch}"},
+			{BranchPattern: "beta.*", ReleaseChannel: semver.ReleaseChannelBeta, VersionPattern: "v{major}.{minor}.{patch}-beta.{build}"},
+		},
+	}
+	err := cfg.Parse()
+	assert.NoError(t, err)
+
+	a := NewAnalyzer(r.repo, cfg, nil)
+	err = a.Load()
+	assert.NoError(t, err)
+
+	// Only commits without increment keep the final version
+	result, err := a.ComputeVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.0.0", result.Version)
+	assert.Equal(t, semver.VersionIncrementLevelNone, result.Increment)
+	assert.Empty(t, result.Reasons)
+	assert.True(t, result.KeepsPreviousVersion())
+	assert.Equal(t, hash.String(), result.Commit)
+	assert.Equal(t, hash.String()[:10], result.ShortCommit)
+
+	// Prereleases still get a new build
+	r.checkout("beta/1", true)
+
+	a = NewAnalyzer(r.repo, cfg, nil)
+	err = a.Load()
+	assert.NoError(t, err)
+
+	result, err = a.ComputeVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.0.0-beta.1", result.Version)
+	assert.False(t, result.KeepsPreviousVersion())
+	assert.Equal(t, hash.String(), result.Commit)
+
+	r.checkout("master", false)
+	r.commit("fix: Some fix")
+
+	a = NewAnalyzer(r.repo, cfg, nil)
+	err = a.Load()
+	assert.NoError(t, err)
+
+	result, err = a.ComputeVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.0.1", result.Version)
+	assert.Equal(t, semver.VersionIncrementLevelPatch, result.Increment)
+}
+
+func TestAnalyzerTaggedHead(t *testing.T) {
+	r := newTestRepo(t)
+	hash := r.commit("Initial commit")
+	r.tag("v1.0.0", hash)
+
+	a := newTestAnalyzer(t, r, nil)
+
+	// Re-tagging the released HEAD results in the existing tag on HEAD
+	result, err := a.ComputeVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.0.0", result.Version)
+	assert.Equal(t, semver.VersionIncrementLevelBuild, result.Increment)
+	assert.False(t, result.KeepsPreviousVersion())
+	assert.Equal(t, hash.String(), result.Commit)
+	assert.Equal(t, "master", result.Branch)
+}
+
+func TestAnalyzerComputeVersion(t *testing.T) {
+	r := newTestRepo(t)
+	hash := r.commit("Initial commit")
+	r.tag("v1.0.0", hash)
+	r.commit("fix: Some fix")
+	featHash := r.commit("feat(api): Some feature")
+	r.commit("docs: Some docs")
+
+	a := newTestAnalyzer(t, r, nil)
+
+	result, err := a.ComputeVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.1.0", result.Version)
+	assert.Equal(t, "v1.0.0", result.PreviousVersion)
+	assert.Equal(t, semver.VersionIncrementLevelMinor, result.Increment)
+	assert.Len(t, result.Reasons, 1)
+	assert.Equal(t, featHash.String(), result.Reasons[0].Hash)
+	assert.Equal(t, "api", result.Reasons[0].Scope)
+	assert.Equal(t, "master", result.Branch)
+	assert.Equal(t, semver.ReleaseChannelFinal, result.ReleaseChannel)
+	assert.Equal(t, 1, result.Major)
+	assert.Equal(t, 1, result.Minor)
+	assert.Equal(t, 0, result.Patch)
+}
+
+func TestAnalyzerGetVersionOptions(t *testing.T) {
+	r := newTestRepo(t)
+	hash := r.commit("Initial commit")
+	r.tag("v1.0.0", hash)
+	r.checkout("feat/test", true)
+	r.commit("fix: Some fix")
+
+	build := 7
+	a := newTestAnalyzer(t, r, &Options{Build: &build})
+
+	version, err := a.GetVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.0.1-feat_test.7", version)
+
+	a = newTestAnalyzer(t, r, &Options{Branch: "develop"})
+
+	_, err = a.GetVersion()
+	assert.ErrorIs(t, err, ErrNoBranchConfig)
+}
+
+func TestAnalyzerGetChangelogHistory(t *testing.T) {
+	r := newTestRepo(t)
+	r.tag("v1.0.0", r.commit("Initial commit"))
+	r.commit("feat: Feature 1")
+	r.tag("v1.1.0", r.commit("fix: Fix 1"))
+	r.checkout("beta-1", true)
+	r.tag("v1.2.0-beta.0", r.commit("feat: Feature 2"))
+	r.checkout("master", false)
+	r.tag("v2.0.0", r.commit("feat!: Feature 3"))
+
+	a := newTestAnalyzer(t, r, nil)
+
+	releases, err := a.GetReleases(semver.ReleaseChannelFinal)
+	assert.NoError(t, err)
+	assert.Len(t, releases, 3)
+	assert.Equal(t, "v1.0.0", releases[0].Tag.Name)
+	assert.Nil(t, releases[0].PreviousTag)
+	assert.Len(t, releases[0].Commits, 1)
+	assert.Equal(t, "v1.1.0", releases[1].Tag.Name)
+	assert.Equal(t, "v1.0.0", releases[1].PreviousTag.Name)
+	assert.Len(t, releases[1].Commits, 2)
+	assert.Equal(t, "v2.0.0", releases[2].Tag.Name)
+	assert.Equal(t, "v1.1.0", releases[2].PreviousTag.Name)
+	assert.Len(t, releases[2].Commits, 1)
+
+	sections, err := a.GetChangelogHistory()
+	assert.NoError(t, err)
+	assert.Len(t, sections, 3)
+	assert.Equal(t, "v2.0.0", sections[0].Version)
+	assert.Equal(t, "v1.1.0", sections[0].PreviousVersion)
+	assert.Contains(t, sections[0].Body, "### BREAKING CHANGES\n* Feature 3 (")
+	assert.Equal(t, "v1.1.0", sections[1].Version)
+	assert.Contains(t, sections[1].Body, "### Features\n* Feature 1 (")
+}
+
+func TestAnalyzerGetChangelogBetween(t *testing.T) {
+	r := newTestRepo(t)
+	r.tag("v1.0.0", r.commit("Initial commit"))
+	r.commit("feat: Feature 1")
+	r.tag("v1.1.0", r.commit("fix: Fix 1"))
+	r.tag("v1.2.0-beta.0", r.commit("feat: Feature 2"))
+	r.tag("v1.2.0", r.commit("fix: Fix 2"))
+	r.commit("feat: Feature 3")
+
+	a := newTestAnalyzer(t, r, nil)
+
+	sections, err := a.GetChangelogSectionsBetween("v1.0.0", "HEAD")
+	assert.NoError(t, err)
+	assert.Len(t, sections, 3)
+	assert.Equal(t, UnreleasedVersion, sections[0].Version)
+	assert.Equal(t, "v1.2.0", sections[0].PreviousVersion)
+	assert.Contains(t, sections[0].Body, "Feature 3")
+	assert.Equal(t, "v1.2.0", sections[1].Version)
+	assert.Equal(t, "v1.1.0", sections[1].PreviousVersion)
+	assert.Contains(t, sections[1].Body, "Feature 2")
+	assert.Contains(t, sections[1].Body, "Fix 2")
+	assert.Equal(t, "v1.1.0", sections[2].Version)
+	assert.Equal(t, "v1.0.0", sections[2].PreviousVersion)
+	assert.NotContains(t, sections[2].Body, "Initial commit")
+
+	changes, err := a.GetChangelogBetween("v1.1.0", "v1.2.0-beta.0")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(changes, "# Features\n* Feature 2 ("))
+}
+
+func TestAnalyzerProject(t *testing.T) {
+	r := newTestRepo(t)
+	hash := r.commitFile("README.md", "Initial commit")
+	r.tag("v1.0.0", hash)
+	r.tag("api/v1.0.0", hash)
+	r.tag("web/v2.0.0", hash)
+	r.commitFile("services/api/main.go", "feat: API feature")
+	r.commitFile("services/web/main.go", "fix: Web fix")
+	r.commitFile("services/apiclient/main.go", "feat!: Client change")
+
+	cfg := &config.Config{
+		Strategy: config.VersionStrategyLatest,
+		Branches: config.DefaultConfig.Branches,
+		Projects: []*config.ProjectConfig{
+			{Name: "api", Path: "./services/api/", TagPrefix: "api/"},
+			{Name: "web", Path: "services/web", TagPrefix: "web/"},
+		},
+	}
+	err := cfg.Parse()
+	assert.NoError(t, err)
+
+	a := NewAnalyzer(r.repo, cfg, &Options{Project: "api"})
+	err = a.Load()
+	assert.NoError(t, err)
+
+	result, err := a.ComputeVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "api", result.Project)
+	assert.Equal(t, "api/v1.1.0", result.Version)
+	assert.Equal(t, "api/v1.0.0", result.PreviousVersion)
+	assert.Len(t, result.Reasons, 1)
+	assert.Equal(t, "API feature", result.Reasons[0].Message)
+
+	a = NewAnalyzer(r.repo, cfg, &Options{Project: "web"})
+	err = a.Load()
+	assert.NoError(t, err)
+
+	result, err = a.ComputeVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "web/v2.0.1", result.Version)
+
+	changes, err := a.GetChangelog()
+	assert.NoError(t, err)
+	assert.Contains(t, changes, "Web fix")
+	assert.NotContains(t, changes, "API feature")
+	assert.NotContains(t, changes, "Client change")
+
+	// The whole repository contains all changes
+	a = NewAnalyzer(r.repo, cfg, nil)
+	err = a.Load()
+	assert.NoError(t, err)
+
+	result, err = a.ComputeVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "v2.0.0", result.Version)
+
+	a = NewAnalyzer(r.repo, cfg, &Options{Project: "unknown"})
+	err = a.Load()
+	assert.Error(t, err)
+}
+
+func TestAnalyzerMavenScheme(t *testing.T) {
+	r := newTestRepo(t)
+	hash := r.commit("Initial commit")
+	r.tag("1.9.0", hash)
+	hash = r.commit("feat: Feature")
+	r.tag("1.10.0", hash)
+	hash = r.commit("fix: Fix")
+	r.tag("1.10.1-SNAPSHOT", hash)
+
+	cfg := &config.Config{
+		Strategy: config.VersionStrategyOverallLatest,
+		Branches: []*config.BranchConfig{
+			{BranchPattern: "master", ReleaseChannel: semver.ReleaseChannelFinal, VersionScheme: semver.VersionSchemeMaven},
+			{BranchPattern: "beta.*", ReleaseChannel: semver.ReleaseChannelBeta, VersionScheme: semver.VersionSchemeMaven, VersionPattern: "{major}.{minor}.{patch}{qualifier}-{build}"},
+			{BranchPattern: "feat.*", VersionScheme: semver.VersionSchemeMaven},
+		},
+	}
+	err := cfg.Parse()
+	assert.NoError(t, err)
+
+	r.checkout("feat/test", true)
+	r.commit("feat: Feature branch")
+
+	a := NewAnalyzer(r.repo, cfg, nil)
+	err = a.Load()
+	assert.NoError(t, err)
+
+	result, err := a.ComputeVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "1.10.0", result.PreviousVersion)
+	assert.Equal(t, "1.11.0-SNAPSHOT", result.Version)
+
+	r.checkout("beta/1", true)
+
+	a = NewAnalyzer(r.repo, cfg, nil)
+	err = a.Load()
+	assert.NoError(t, err)
+
+	result, err = a.ComputeVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "1.11.0-beta-0", result.Version)
+}
+
+func TestGetReleasePlan(t *testing.T) {
+	r := newTestRepo(t)
+	hash := r.commitFile("README.md", "Initial commit")
+	r.tag("lib/v1.0.0", hash)
+	r.tag("api/v1.0.0", hash)
+	r.tag("web/v1.0.0", hash)
+	r.tag("docs/v1.0.0", hash)
+	r.commitFile("lib/lib.go", "feat!: Breaking library change")
+
+	cfg := &config.Config{
+		Strategy: config.VersionStrategyLatest,
+		Branches: config.DefaultConfig.Branches,
+		Projects: []*config.ProjectConfig{
+			{Name: "web", Path: "web", TagPrefix: "web/", DependsOn: []string{"api"}, DependencyIncrement: config.DependencyIncrementSame},
+			{Name: "api", Path: "api", TagPrefix: "api/", DependsOn: []string{"lib"}, DependencyIncrement: config.DependencyIncrementMinor},
+			{Name: "lib", Path: "lib", TagPrefix: "lib/"},
+			{Name: "docs", Path: "docs", TagPrefix: "docs/"},
+		},
+	}
+	err := cfg.Parse()
+	assert.NoError(t, err)
+
+	plan, err := GetReleasePlan(r.repo, cfg, nil)
+	assert.NoError(t, err)
+	assert.Len(t, plan, 4)
+
+	assert.Equal(t, "lib/v2.0.0", plan[0].Version)
+	assert.True(t, plan[0].HasChanges())
+	assert.Equal(t, "api/v1.1.0", plan[1].Version)
+	assert.Len(t, plan[1].Dependencies, 1)
+	assert.Equal(t, "lib", plan[1].Dependencies[0].Project)
+	assert.Equal(t, semver.VersionIncrementLevelMinor, plan[1].Dependencies[0].Increment)
+	assert.Equal(t, "web/v1.1.0", plan[2].Version)
+	assert.True(t, plan[2].HasChanges())
+	assert.Equal(t, "docs", plan[3].Project)
+	assert.False(t, plan[3].HasChanges())
+
+	// Versions of single projects include dependency increments
+	a := NewAnalyzer(r.repo, cfg, &Options{Project: "web"})
+	err = a.Load()
+	assert.NoError(t, err)
+
+	result, err := a.ComputeVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "web/v1.1.0", result.Version)
+}
+
+func TestConfigCyclicProjectDependencies(t *testing.T) {
+	cfg := &config.Config{
+		Strategy: config.VersionStrategyLatest,
+		Branches: config.DefaultConfig.Branches,
+		Projects: []*config.ProjectConfig{
+			{Name: "a", Path: "a", DependsOn: []string{"b"}},
+			{Name: "b", Path: "b", DependsOn: []string{"a"}},
+		},
+	}
+
+	err := cfg.Parse()
+	assert.ErrorContains(t, err, "cyclic project dependency a -> b -> a")
+}
+
+func TestAnalyzerCalendarVersion(t *testing.T) {
+	r := newTestRepo(t)
+	hash := r.commit("Initial commit")
+	r.tag("v2021.01.0", hash)
+	r.commit("fix: Some fix")
+
+	cfg := &config.Config{
+		Strategy: config.VersionStrategyOverallLatest,
+		Branches: []*config.BranchConfig{
+			{BranchPattern: "master", ReleaseChannel: semver.ReleaseChannelFinal, VersionPattern: "v{yyyy}.{0m}.{patch}"},
+		},
+	}
+	err := cfg.Parse()
+	assert.NoError(t, err)
+
+	a := NewAnalyzer(r.repo, cfg, nil)
+	err = a.Load()
+	assert.NoError(t, err)
+
+	// Same month as the commit date of HEAD
+	result, err := a.ComputeVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "v2021.01.0", result.PreviousVersion)
+	assert.Equal(t, "v2021.01.1", result.Version)
+
+	date := time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC)
+	a = NewAnalyzer(r.repo, cfg, &Options{Date: &date})
+	err = a.Load()
+	assert.NoError(t, err)
+
+	// New month resets the micro number
+	result, err = a.ComputeVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "v2021.03.0", result.Version)
+}
+
+func TestAnalyzerCalendarVersionMinor(t *testing.T) {
+	r := newTestRepo(t)
+	r.tag("v24.2.3", r.commit("Initial commit"))
+	r.commit("feat: Some feature")
+
+	cfg := &config.Config{
+		Strategy: config.VersionStrategyOverallLatest,
+		Branches: []*config.BranchConfig{
+			{BranchPattern: "master", ReleaseChannel: semver.ReleaseChannelFinal, VersionPattern: "v{yy}.{minor}.{patch}"},
+		},
+	}
+	err := cfg.Parse()
+	assert.NoError(t, err)
+
+	date := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	a := NewAnalyzer(r.repo, cfg, &Options{Date: &date})
+	err = a.Load()
+	assert.NoError(t, err)
+
+	// Features increment the minor number within the same year
+	result, err := a.ComputeVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "v24.3.0", result.Version)
+	assert.Equal(t, semver.VersionIncrementLevelMinor, result.Increment)
+
+	date = time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	a = NewAnalyzer(r.repo, cfg, &Options{Date: &date})
+	err = a.Load()
+	assert.NoError(t, err)
+
+	// A new year resets the minor and micro number
+	result, err = a.ComputeVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "v25.0.0", result.Version)
+
+	cfg.Branches[0].VersionPattern = "v{yyyy}.{0m}.{patch}"
+	err = cfg.Parse()
+	assert.NoError(t, err)
+	r.tag("v2024.06.4", r.commit("fix: Some fix"))
+	r.commit("feat!: Another breaking change")
+
+	date = time.Date(2024, 6, 20, 0, 0, 0, 0, time.UTC)
+	a = NewAnalyzer(r.repo, cfg, &Options{Date: &date})
+	err = a.Load()
+	assert.NoError(t, err)
+
+	// Without {major} and {minor} any change increments the micro number
+	result, err = a.ComputeVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "v2024.06.5", result.Version)
+	assert.Equal(t, semver.VersionIncrementLevelPatch, result.Increment)
+}
+
+func TestAnalyzerCalendarVersionISOWeek(t *testing.T) {
+	r := newTestRepo(t)
+	r.tag("v24.52.0", r.commit("Initial commit"))
+	r.commit("fix: Some fix")
+
+	cfg := &config.Config{
+		Strategy: config.VersionStrategyOverallLatest,
+		Branches: []*config.BranchConfig{
+			{BranchPattern: "master", ReleaseChannel: semver.ReleaseChannelFinal, VersionPattern: "v{yy}.{ww}.{patch}"},
+		},
+	}
+	err := cfg.Parse()
+	assert.NoError(t, err)
+
+	// 2024-12-30 is in week 1 of 2025
+	date := time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC)
+	a := NewAnalyzer(r.repo, cfg, &Options{Date: &date})
+	err = a.Load()
+	assert.NoError(t, err)
+
+	result, err := a.ComputeVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "v25.1.0", result.Version)
+
+	// Months and days keep the calendar year
+	versionInfo := &semver.VersionInfo{}
+	versionInfo.SetDate(date, false)
+	assert.Equal(t, 2024, versionInfo.Year)
+	assert.Equal(t, 12, versionInfo.Month)
+	assert.Equal(t, 1, versionInfo.Week)
+}
+
+func TestAnalyzerPromote(t *testing.T) {
+	r := newTestRepo(t)
+	hash := r.commit("Initial commit")
+	r.tag("v2.0.0", hash)
+	hash = r.commit("feat: Some feature")
+	r.tag("v2.1.0-beta.3", hash)
+	r.commit("fix: Some fix")
+
+	a := newTestAnalyzer(t, r, nil)
+
+	promotion, err := a.Promote("v2.1.0-beta.3", semver.ReleaseChannelNone)
+	assert.NoError(t, err)
+	assert.Equal(t, "v2.1.0-gamma.0", promotion.Version)
+	assert.Equal(t, semver.ReleaseChannelGamma, promotion.ReleaseChannel)
+	assert.Equal(t, hash.String(), promotion.Commit)
+
+	promotion, err = a.Promote("v2.1.0-beta.3", semver.ReleaseChannelFinal)
+	assert.NoError(t, err)
+	assert.Equal(t, "v2.1.0", promotion.Version)
+
+	_, err = a.Promote("v2.0.0", semver.ReleaseChannelNone)
+	assert.ErrorIs(t, err, ErrPromotionNotPossible)
+
+	_, err = a.Promote("v2.1.0-beta.3", semver.ReleaseChannelAlpha)
+	assert.ErrorIs(t, err, ErrPromotionNotPossible)
+
+	_, err = a.Promote("v9.9.9-beta.0", semver.ReleaseChannelNone)
+	assert.ErrorIs(t, err, ErrPromotionNotPossible)
+
+	// Existing gamma tag of the same version
+	r.tag("v2.1.0-gamma.0", hash)
+	a = newTestAnalyzer(t, r, nil)
+
+	promotion, err = a.Promote("v2.1.0-beta.3", semver.ReleaseChannelNone)
+	assert.NoError(t, err)
+	assert.Equal(t, "v2.1.0-gamma.1", promotion.Version)
+
+	// Existing higher version
+	r.tag("v2.2.0", hash)
+	a = newTestAnalyzer(t, r, nil)
+
+	_, err = a.Promote("v2.1.0-beta.3", semver.ReleaseChannelNone)
+	assert.ErrorIs(t, err, ErrPromotionNotPossible)
+}
+
+func TestAnalyzerCustomReleaseChannels(t *testing.T) {
+	r := newTestRepo(t)
+	hash := r.commit("Initial commit")
+	r.tag("v1.0.0", hash)
+	hash = r.commit("feat: Some feature")
+	r.tag("v1.1.0-nightly.0", hash)
+
+	release := false
+	cfg := &config.Config{
+		Strategy: config.VersionStrategyLatest,
+		ReleaseChannels: []*semver.ReleaseChannelDefinition{
+			{Name: "NIGHTLY", Priority: 1, Release: &release},
+			{Name: "RC", Priority: 2},
+			{Name: semver.ReleaseChannelFinal, Priority: 3},
+		},
+		Branches: []*config.BranchConfig{
+			{BranchPattern: "master", ReleaseChannel: semver.ReleaseChannelFinal, VersionPattern: "v{major}.{minor}.{patch}"},
+			{BranchPattern: "rc.*", ReleaseChannel: "RC", VersionPattern: "v{major}.{minor}.{patch}-rc.{build}"},
+			{BranchPattern: "nightly", ReleaseChannel: "NIGHTLY", VersionPattern: "v{major}.{minor}.{patch}-nightly.{build}"},
+		},
+	}
+	err := cfg.Parse()
+	assert.NoError(t, err)
+
+	r.checkout("rc/1", true)
+
+	a := NewAnalyzer(r.repo, cfg, nil)
+	err = a.Load()
+	assert.NoError(t, err)
+
+	// Nightly versions are no releases
+	result, err := a.ComputeVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.0.0", result.PreviousVersion)
+	assert.Equal(t, "v1.1.0-rc.0", result.Version)
+
+	_, err = a.Promote("v1.1.0-nightly.0", semver.ReleaseChannelNone)
+	assert.ErrorIs(t, err, ErrPromotionNotPossible)
+
+	r.tag("v1.1.0-rc.0", hash)
+
+	a = NewAnalyzer(r.repo, cfg, nil)
+	err = a.Load()
+	assert.NoError(t, err)
+
+	promotion, err := a.Promote("v1.1.0-rc.0", semver.ReleaseChannelNone)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.1.0", promotion.Version)
+
+	cfg.Branches = append(cfg.Branches, &config.BranchConfig{BranchPattern: "beta", ReleaseChannel: semver.ReleaseChannelBeta, VersionPattern: "v{major}.{minor}.{patch}-beta.{build}"})
+	err = cfg.Parse()
+	assert.Error(t, err)
+}
+
+func TestAnalyzerLowestReleaseChannel(t *testing.T) {
+	r := newTestRepo(t)
+	r.tag("v1.0.0", r.commit("Initial commit"))
+	r.checkout("edge", true)
+	r.tag("v1.0.1-edge.0", r.commit("fix: Some fix for #1"))
+	r.commit("fix: Another fix for #2")
+
+	cfg := &config.Config{
+		Strategy: config.VersionStrategyLatest,
+		ReleaseChannels: []*semver.ReleaseChannelDefinition{
+			{Name: "EDGE", Priority: 1},
+			{Name: semver.ReleaseChannelAlpha, Priority: 2},
+			{Name: semver.ReleaseChannelFinal, Priority: 3},
+		},
+		Branches: []*config.BranchConfig{
+			{BranchPattern: "master", ReleaseChannel: semver.ReleaseChannelFinal, VersionPattern: "v{major}.{minor}.{patch}"},
+			{BranchPattern: "edge", ReleaseChannel: "EDGE", VersionPattern: "v{major}.{minor}.{patch}-edge.{build}"},
+		},
+	}
+	err := cfg.Parse()
+	assert.NoError(t, err)
+	assert.Equal(t, semver.ReleaseChannel("EDGE"), cfg.GetReleaseChannels().GetLowestRelease())
+
+	a := NewAnalyzer(r.repo, cfg, nil)
+	err = a.Load()
+	assert.NoError(t, err)
+
+	// EDGE versions are releases even though their priority is below ALPHA
+	issues, err := a.GetIssues()
+	assert.NoError(t, err)
+	assert.Len(t, issues, 1)
+	assert.Equal(t, "#2", issues[0].Issue)
+}
+
+func TestAnalyzerBuildCounter(t *testing.T) {
+	r := newTestRepo(t)
+	hash := r.commit("Initial commit")
+	r.tag("v1.2.0", hash)
+
+	r.checkout("beta/1", true)
+	hash = r.commit("feat: Some feature")
+	r.tag("v1.3.0-beta.1", hash)
+	hash = r.commit("fix: Some fix")
+	r.tag("v1.3.0-beta.2", hash)
+	r.tag("v1.4.0-beta.7", hash)
+	r.commit("fix: Another fix")
+
+	cfg := &config.Config{
+		Strategy: config.VersionStrategyLatest,
+		Branches: []*config.BranchConfig{
+			{BranchPattern: "master", ReleaseChannel: semver.ReleaseChannelFinal, VersionPattern: "v{major}.{minor}.{patch}"},
+			{BranchPattern: "beta.*", ReleaseChannel: semver.ReleaseChannelBeta, VersionPattern: "v{major}.{minor}.{patch}-beta.{build}"},
+		},
+	}
+	err := cfg.Parse()
+	assert.NoError(t, err)
+
+	a := NewAnalyzer(r.repo, cfg, nil)
+	err = a.Load()
+	assert.NoError(t, err)
+
+	result, err := a.ComputeVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.3.0-beta.0", result.Version)
+
+	cfg.Branches[1].BuildCounter = config.BuildCounterContinue
+
+	result, err = a.ComputeVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.3.0-beta.3", result.Version)
+
+	build := 10
+	a = NewAnalyzer(r.repo, cfg, &Options{Build: &build})
+	err = a.Load()
+	assert.NoError(t, err)
+
+	result, err = a.ComputeVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.3.0-beta.10", result.Version)
+}
+
+func TestAnalyzerExplain(t *testing.T) {
+	r := newTestRepo(t)
+	tagHash := r.commit("Initial commit")
+	r.tag("v1.0.0", tagHash)
+	r.checkout("feat/test", true)
+	fixHash := r.commit("fix: Some fix")
+	featHash := r.commit("feat(api): Some feature")
+
+	a := newTestAnalyzer(t, r, nil)
+
+	explanation, err := a.Explain()
+	assert.NoError(t, err)
+	assert.Equal(t, "feat/test", explanation.Branch)
+	assert.False(t, explanation.BranchFromOption)
+	assert.Equal(t, "feat.*", explanation.GetBranchConfig().BranchPattern)
+	assert.Len(t, explanation.BranchConfigs, 6)
+	assert.Equal(t, config.VersionStrategyLatest, explanation.Strategy)
+	assert.Equal(t, "v1.0.0", explanation.BaseTag)
+	assert.Equal(t, []string{featHash.String(), fixHash.String(), tagHash.String()}, explanation.BaseTagPath)
+
+	assert.Len(t, explanation.Commits, 2)
+	assert.Equal(t, featHash.String(), explanation.Commits[0].Hash)
+	assert.Equal(t, "feat", explanation.Commits[0].CommitType)
+	assert.Equal(t, "api", explanation.Commits[0].Scope)
+	assert.Equal(t, semver.VersionIncrementLevelMinor, explanation.Commits[0].Increment)
+	assert.True(t, explanation.Commits[0].Reason)
+	assert.Equal(t, semver.VersionIncrementLevelPatch, explanation.Commits[1].Increment)
+	assert.False(t, explanation.Commits[1].Reason)
+
+	assert.Equal(t, semver.VersionIncrementLevelMinor, explanation.Result.Increment)
+	assert.Equal(t, "v1.1.0-feat_test.0", explanation.Result.Version)
+
+	a = newTestAnalyzer(t, r, &Options{Branch: "unknown"})
+
+	explanation, err = a.Explain()
+	assert.NoError(t, err)
+	assert.True(t, explanation.BranchFromOption)
+	assert.Nil(t, explanation.GetBranchConfig())
+	assert.Nil(t, explanation.Result)
+}
+
+func TestAnalyzerGetGraph(t *testing.T) {
+	r := newTestRepo(t)
+	initialHash := r.commit("Initial commit")
+	r.tag("v1.0.0", initialHash)
+	majorHash := r.commit("feat!: Breaking change")
+	r.tag("v2.0.0", majorHash)
+	minorHash := r.commit("feat: Some feature")
+	r.tag("v1.1.0", minorHash)
+	r.checkout("feat/test", true)
+	fixHash := r.commit("fix: Some fix\n\nDetails")
+
+	a := newTestAnalyzer(t, r, nil)
+
+	history, err := a.GetGraph(0)
+	assert.NoError(t, err)
+	assert.Len(t, history.Commits, 4)
+
+	assert.Equal(t, fixHash.String(), history.Commits[0].Hash)
+	assert.Equal(t, "fix: Some fix", history.Commits[0].Subject)
+	assert.True(t, history.Commits[0].IsHead)
+	assert.Equal(t, []string{"feat/test"}, history.Commits[0].Branches)
+	assert.Equal(t, []string{minorHash.String()}, history.Commits[0].Parents)
+
+	assert.Equal(t, []string{"master"}, history.Commits[1].Branches)
+	assert.Len(t, history.Commits[1].Tags, 1)
+	assert.Equal(t, "v1.1.0", history.Commits[1].Tags[0].Name)
+	assert.Equal(t, 1, history.Commits[1].Tags[0].Version.Minor)
+	assert.Equal(t, initialHash.String(), history.Commits[3].Hash)
+	assert.Empty(t, history.Commits[3].Parents)
+
+	assert.Len(t, history.Strategies, 3)
+	assert.Equal(t, "LATEST", history.Strategies[0].Name)
+	assert.Equal(t, "v1.1.0", history.Strategies[0].BaseTag.Name)
+	assert.Equal(t, "CLOSEST", history.Strategies[1].Name)
+	assert.Equal(t, "v1.1.0", history.Strategies[1].BaseTag.Name)
+	assert.Equal(t, "v1.1.1-feat_test.0", history.Strategies[1].Version)
+	assert.Equal(t, "OVERALL_LATEST", history.Strategies[2].Name)
+	assert.Equal(t, "v2.0.0", history.Strategies[2].BaseTag.Name)
+	assert.Equal(t, "v2.0.1-feat_test.0", history.Strategies[2].Version)
+
+	history, err = a.GetGraph(2)
+	assert.NoError(t, err)
+	assert.Len(t, history.Commits, 2)
+	assert.Empty(t, history.Commits[1].Parents)
+
+	a = newTestAnalyzer(t, r, &Options{Branch: "unknown"})
+
+	history, err = a.GetGraph(0)
+	assert.NoError(t, err)
+	assert.Len(t, history.Commits, 4)
+	assert.Empty(t, history.Strategies)
+}
+
+func TestAnalyzerGetPublication(t *testing.T) {
+	r := newTestRepo(t)
+	r.tag("v1.0.0", r.commit("Initial commit"))
+	r.commit("feat: Some feature")
+	r.tag("v1.1.0", r.commit("fix: Some fix"))
+
+	a := newTestAnalyzer(t, r, nil)
+
+	publication, err := a.GetPublication()
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.1.0", publication.Tag)
+	assert.Equal(t, "v1.0.0", publication.PreviousTag)
+	assert.Equal(t, semver.ReleaseChannelFinal, publication.ReleaseChannel)
+	assert.False(t, publication.Prerelease)
+	assert.Contains(t, publication.Changelog, "## Features\n")
+	assert.Contains(t, publication.Changelog, "Some feature")
+	assert.Contains(t, publication.Changelog, "Some fix")
+
+	r.checkout("beta/test", true)
+	r.tag("v1.2.0-beta.0", r.commit("feat: Beta feature"))
+
+	a = newTestAnalyzer(t, r, nil)
+
+	publication, err = a.GetPublication()
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.2.0-beta.0", publication.Tag)
+	assert.Equal(t, "v1.1.0", publication.PreviousTag)
+	assert.True(t, publication.Prerelease)
+	assert.Contains(t, publication.Changelog, "Beta feature")
+	assert.NotContains(t, publication.Changelog, "Some fix")
+
+	r.commit("fix: Untagged fix")
+
+	a = newTestAnalyzer(t, r, nil)
+
+	_, err = a.GetPublication()
+	assert.ErrorIs(t, err, ErrNoVersionTag)
+}
+
+func TestAnalyzerGetIssues(t *testing.T) {
+	r := newTestRepo(t)
+	r.tag("v1.0.0", r.commit("fix: Old fix for #1"))
+	r.commit("feat: Some feature\n\nRefs #2")
+	r.commit("fix(api): Some fix\n\nCloses #2, #3\n\nSHA-256 of UTF-8 names")
+
+	a := newTestAnalyzer(t, r, nil)
+
+	issues, err := a.GetIssues()
+	assert.NoError(t, err)
+	assert.Len(t, issues, 2)
+
+	assert.Equal(t, "#2", issues[0].Issue)
+	assert.Equal(t, "2", issues[0].ID)
+	assert.Equal(t, "github", issues[0].Tracker)
+	assert.Len(t, issues[0].Commits, 2)
+	assert.Equal(t, "Some feature", issues[0].Commits[0].Message)
+	assert.Equal(t, "api", issues[0].Commits[1].Scope)
+
+	assert.Equal(t, "#3", issues[1].Issue)
+	assert.Equal(t, "github", issues[1].Tracker)
+}
+
+func TestAnalyzerContributors(t *testing.T) {
+	r := newTestRepo(t)
+	r.tag("v1.0.0", r.commitAs("Jane", "jane@old.example.com", "Initial commit"))
+	r.commitAs("Bob", "bob@example.com", "feat: Some feature\n\nCo-authored-by: Alice <alice@example.com>")
+	r.commitAs("Jane", "jane@example.com", "fix: Some fix")
+
+	mailmapFilename := filepath.Join(t.TempDir(), ".mailmap")
+	err := ioutil.WriteFile(mailmapFilename, []byte("Jane Doe <jane@example.com> <jane@old.example.com>\nJane Doe <jane@example.com>\n"), 0644)
+	assert.NoError(t, err)
+
+	cfg := *config.DefaultConfig
+	cfg.Changelog = &config.ChangelogConfig{
+		Mailmap:      mailmapFilename,
+		Contributors: true,
+	}
+	err = cfg.Parse()
+	assert.NoError(t, err)
+
+	a := NewAnalyzer(r.repo, &cfg, nil)
+	err = a.Load()
+	assert.NoError(t, err)
+
+	changelog, err := a.GetChangelog()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasSuffix(changelog, "# Contributors\n"+
+		"* Alice (first contribution)\n"+
+		"* Bob (first contribution)\n"+
+		"* Jane Doe\n"+
+		"\n"), changelog)
+}
@@ -0,0 +1,200 @@
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/indece-official/semantic-version/pkg/semver"
+	"github.com/indece-official/semantic-version/pkg/semver/changelog"
+	"github.com/indece-official/semantic-version/pkg/semver/config"
+)
+
+// ExplanationBranchConfig is a branch config checked against the current
+// branch
+type ExplanationBranchConfig struct {
+	BranchPattern  string                `json:"branch_pattern" yaml:"branch_pattern"`
+	ReleaseChannel semver.ReleaseChannel `json:"release_channel" yaml:"release_channel"`
+	Matches        bool                  `json:"matches" yaml:"matches"`
+}
+
+// ExplanationCommit is a change considered for the version increment
+type ExplanationCommit struct {
+	Hash    string `json:"hash" yaml:"hash"`
+	Type    string `json:"type,omitempty" yaml:"type,omitempty"`
+	Scope   string `json:"scope,omitempty" yaml:"scope,omitempty"`
+	Message string `json:"message" yaml:"message"`
+	// CommitType is the configured commit type matching Type, empty if unknown
+	CommitType string                       `json:"commit_type,omitempty" yaml:"commit_type,omitempty"`
+	Breaking   bool                         `json:"breaking" yaml:"breaking"`
+	Increment  semver.VersionIncrementLevel `json:"increment" yaml:"increment"`
+	// Reason is true if the change causes the resulting version increment
+	Reason bool `json:"reason" yaml:"reason"`
+}
+
+// Explanation describes how the version of the current branch is computed
+type Explanation struct {
+	Project string `json:"project,omitempty" yaml:"project,omitempty"`
+	Branch  string `json:"branch" yaml:"branch"`
+	// BranchFromOption is true if the branch name is set by Options.Branch
+	// instead of being detected from HEAD
+	BranchFromOption bool `json:"branch_from_option" yaml:"branch_from_option"`
+	// BranchConfigs contains the branch configs checked in order until the
+	// first matching one
+	BranchConfigs []*ExplanationBranchConfig `json:"branch_configs" yaml:"branch_configs"`
+	Strategy      config.VersionStrategy     `json:"strategy" yaml:"strategy"`
+	// BaseTag is the final release tag selected by the strategy, empty if
+	// there is no final release
+	BaseTag       string `json:"base_tag,omitempty" yaml:"base_tag,omitempty"`
+	BaseTagCommit string `json:"base_tag_commit,omitempty" yaml:"base_tag_commit,omitempty"`
+	// BaseTagPath contains the hashes of the shortest commit path from HEAD
+	// to the commit of the base tag (both included)
+	BaseTagPath []string             `json:"base_tag_path,omitempty" yaml:"base_tag_path,omitempty"`
+	Commits     []*ExplanationCommit `json:"commits" yaml:"commits"`
+	// Result is the computed version, nil if no branch config matches
+	Result *VersionResult `json:"result" yaml:"result"`
+}
+
+// GetBranchConfig returns the matching branch config, nil if none matches
+func (e *Explanation) GetBranchConfig() *ExplanationBranchConfig {
+	for _, branchConfig := range e.BranchConfigs {
+		if branchConfig.Matches {
+			return branchConfig
+		}
+	}
+
+	return nil
+}
+
+// getCommitPath returns the hashes of the shortest path following the parents
+// from commit to the commit target, nil if target isn't an ancestor
+func (a *Analyzer) getCommitPath(commit *object.Commit, target plumbing.Hash) ([]string, error) {
+	predecessors := map[plumbing.Hash]plumbing.Hash{}
+	queue := []*object.Commit{commit}
+	seen := map[plumbing.Hash]bool{commit.Hash: true}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current.Hash == target {
+			path := []string{}
+			for hash := target; ; hash = predecessors[hash] {
+				path = append([]string{hash.String()}, path...)
+				if hash == commit.Hash {
+					return path, nil
+				}
+			}
+		}
+
+		err := current.Parents().ForEach(func(parent *object.Commit) error {
+			if seen[parent.Hash] {
+				return nil
+			}
+
+			seen[parent.Hash] = true
+			predecessors[parent.Hash] = current.Hash
+			queue = append(queue, parent)
+
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("can't load parents of commit %s: %s", current.Hash.String(), err)
+		}
+	}
+
+	return nil, nil
+}
+
+// Explain computes the version of the current branch like ComputeVersion and
+// describes all steps of the computation
+//
+// If no branch config matches the current branch, the explanation contains
+// only the checked branch configs.
+func (a *Analyzer) Explain() (*Explanation, error) {
+	branchName, branchConfig, err := a.GetCurrentBranchConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error getting branch config: %s", err)
+	}
+
+	explanation := &Explanation{
+		Project:          a.options.Project,
+		Branch:           branchName,
+		BranchFromOption: a.options.Branch != "",
+		BranchConfigs:    []*ExplanationBranchConfig{},
+		Strategy:         a.cfg.Strategy,
+		Commits:          []*ExplanationCommit{},
+	}
+
+	for _, checkedBranchConfig := range a.getBranchConfigs() {
+		explanation.BranchConfigs = append(explanation.BranchConfigs, &ExplanationBranchConfig{
+			BranchPattern:  checkedBranchConfig.BranchPattern,
+			ReleaseChannel: checkedBranchConfig.ReleaseChannel,
+			Matches:        checkedBranchConfig == branchConfig,
+		})
+
+		if checkedBranchConfig == branchConfig {
+			break
+		}
+	}
+
+	if branchConfig == nil {
+		return explanation, nil
+	}
+
+	explanation.Result, err = a.ComputeVersion()
+	if err != nil {
+		if errors.Is(err, ErrNoBranchConfig) {
+			return explanation, nil
+		}
+
+		return nil, err
+	}
+
+	highestTag, err := a.GetHighestFinalReleaseTag()
+	if err != nil {
+		return nil, fmt.Errorf("error getting highest final release: %s", err)
+	}
+
+	if highestTag != nil {
+		explanation.BaseTag = highestTag.Name
+		explanation.BaseTagCommit = highestTag.Commit
+
+		explanation.BaseTagPath, err = a.getCommitPath(a.headCommit, plumbing.NewHash(highestTag.Commit))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	commits, err := a.GetCommitsSinceLastRelease(branchConfig, semver.ReleaseChannelFinal)
+	if err != nil {
+		return nil, fmt.Errorf("error loading commits since last release: %s", err)
+	}
+
+	for _, commit := range commits {
+		for _, parsedCommit := range changelog.ParseCommitMessageWithIssuePatterns(commit.Message, commit.Hash.String(), a.cfg.IssuePatterns) {
+			parsedCommit.CommitType = changelog.FindCommitType(a.cfg.CommitTypes, parsedCommit.Type)
+
+			explanationCommit := &ExplanationCommit{
+				Hash:      parsedCommit.Hash,
+				Type:      parsedCommit.Type,
+				Scope:     parsedCommit.Scope,
+				Message:   parsedCommit.Message,
+				Breaking:  parsedCommit.IsBreaking(),
+				Increment: parsedCommit.GetIncrementLevel(),
+			}
+
+			if parsedCommit.CommitType != nil {
+				explanationCommit.CommitType = parsedCommit.CommitType.Type
+			}
+
+			explanationCommit.Reason = explanation.Result.Increment != semver.VersionIncrementLevelNone &&
+				explanationCommit.Increment == explanation.Result.Increment
+
+			explanation.Commits = append(explanation.Commits, explanationCommit)
+		}
+	}
+
+	return explanation, nil
+}
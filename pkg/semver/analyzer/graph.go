@@ -0,0 +1,223 @@
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/indece-official/semantic-version/pkg/semver"
+	"github.com/indece-official/semantic-version/pkg/semver/config"
+	"github.com/indece-official/semantic-version/pkg/semver/graph"
+)
+
+// graphStrategies contains the strategies annotated in the graph
+var graphStrategies = []config.VersionStrategy{
+	config.VersionStrategyLatest,
+	config.VersionStrategyClosest,
+	config.VersionStrategyOverallLatest,
+}
+
+// getGraphStrategies computes the version of HEAD with each strategy, empty
+// if no branch config matches the current branch
+func (a *Analyzer) getGraphStrategies() ([]*graph.Strategy, error) {
+	strategies := []*graph.Strategy{}
+
+	for _, strategy := range graphStrategies {
+		cfg := *a.cfg
+		cfg.Strategy = strategy
+
+		strategyAnalyzer := *a
+		strategyAnalyzer.cfg = &cfg
+
+		result, err := strategyAnalyzer.ComputeVersion()
+		if err != nil {
+			if errors.Is(err, ErrNoBranchConfig) {
+				return []*graph.Strategy{}, nil
+			}
+
+			return nil, fmt.Errorf("error computing version with strategy %s: %s", strategy, err)
+		}
+
+		baseTag, err := strategyAnalyzer.GetHighestFinalReleaseTag()
+		if err != nil {
+			return nil, fmt.Errorf("error getting highest final release with strategy %s: %s", strategy, err)
+		}
+
+		strategies = append(strategies, &graph.Strategy{
+			Name:    string(strategy),
+			BaseTag: baseTag,
+			Version: result.Version,
+		})
+	}
+
+	return strategies, nil
+}
+
+// getGraphCommits loads all commits reachable from HEAD and the local branches
+// mapped by hash
+func (a *Analyzer) getGraphCommits() (map[plumbing.Hash]*object.Commit, map[plumbing.Hash][]string, error) {
+	commits := map[plumbing.Hash]*object.Commit{}
+	branches := map[plumbing.Hash][]string{}
+	queue := []*object.Commit{a.headCommit}
+
+	branchRefs, err := a.repo.Branches()
+	if err != nil {
+		return nil, nil, fmt.Errorf("can't load branches: %s", err)
+	}
+
+	err = branchRefs.ForEach(func(ref *plumbing.Reference) error {
+		branches[ref.Hash()] = append(branches[ref.Hash()], ref.Name().Short())
+
+		commit, err := a.repo.CommitObject(ref.Hash())
+		if err != nil {
+			return fmt.Errorf("can't load commit of branch %s: %s", ref.Name().Short(), err)
+		}
+
+		queue = append(queue, commit)
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, names := range branches {
+		sort.Strings(names)
+	}
+
+	for len(queue) > 0 {
+		commit := queue[0]
+		queue = queue[1:]
+
+		if _, exists := commits[commit.Hash]; exists {
+			continue
+		}
+
+		commits[commit.Hash] = commit
+
+		err := commit.Parents().ForEach(func(parent *object.Commit) error {
+			queue = append(queue, parent)
+
+			return nil
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("can't load parents of commit %s: %s", commit.Hash.String(), err)
+		}
+	}
+
+	return commits, branches, nil
+}
+
+// sortGraphCommits sorts the commits topologically with children before their
+// parents, newer commits (by commit date) are placed first
+func sortGraphCommits(commits map[plumbing.Hash]*object.Commit) []*object.Commit {
+	children := map[plumbing.Hash]int{}
+	for _, commit := range commits {
+		for _, parent := range commit.ParentHashes {
+			children[parent]++
+		}
+	}
+
+	ready := []*object.Commit{}
+	for hash, commit := range commits {
+		if children[hash] == 0 {
+			ready = append(ready, commit)
+		}
+	}
+
+	sorted := []*object.Commit{}
+
+	for len(ready) > 0 {
+		sort.Slice(ready, func(i, j int) bool {
+			if !ready[i].Committer.When.Equal(ready[j].Committer.When) {
+				return ready[i].Committer.When.After(ready[j].Committer.When)
+			}
+
+			return ready[i].Hash.String() < ready[j].Hash.String()
+		})
+
+		commit := ready[0]
+		ready = ready[1:]
+		sorted = append(sorted, commit)
+
+		for _, parent := range commit.ParentHashes {
+			children[parent]--
+			if children[parent] == 0 {
+				ready = append(ready, commits[parent])
+			}
+		}
+	}
+
+	return sorted
+}
+
+// GetGraph returns the history of HEAD and all local branches with the
+// version tags and the versions of HEAD computed with each strategy
+//
+// Only the newest maxCommits commits are contained if maxCommits > 0.
+func (a *Analyzer) GetGraph(maxCommits int) (*graph.Graph, error) {
+	commits, branches, err := a.getGraphCommits()
+	if err != nil {
+		return nil, err
+	}
+
+	sortedCommits := sortGraphCommits(commits)
+	if maxCommits > 0 && len(sortedCommits) > maxCommits {
+		sortedCommits = sortedCommits[:maxCommits]
+	}
+
+	contained := map[plumbing.Hash]bool{}
+	for _, commit := range sortedCommits {
+		contained[commit.Hash] = true
+	}
+
+	strategies, err := a.getGraphStrategies()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &graph.Graph{
+		Commits:    []*graph.Commit{},
+		Strategies: strategies,
+	}
+
+	for _, commit := range sortedCommits {
+		graphCommit := &graph.Commit{
+			Hash:     commit.Hash.String(),
+			Parents:  []string{},
+			Subject:  strings.SplitN(strings.TrimSpace(commit.Message), "\n", 2)[0],
+			Branches: branches[commit.Hash],
+			Tags:     []*semver.Tag{},
+			IsHead:   commit.Hash == a.headCommit.Hash,
+		}
+
+		for _, parent := range commit.ParentHashes {
+			if contained[parent] {
+				graphCommit.Parents = append(graphCommit.Parents, parent.String())
+			}
+		}
+
+		// A tag matching the version patterns of multiple branch configs is
+		// only shown once
+		tagNames := map[string]bool{}
+		for _, tag := range a.mapCommitTags[graphCommit.Hash] {
+			if tagNames[tag.Name] {
+				continue
+			}
+
+			tagNames[tag.Name] = true
+			graphCommit.Tags = append(graphCommit.Tags, tag)
+		}
+
+		sort.Slice(graphCommit.Tags, func(i, j int) bool {
+			return graphCommit.Tags[i].Name < graphCommit.Tags[j].Name
+		})
+
+		result.Commits = append(result.Commits, graphCommit)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,83 @@
+package analyzer
+
+import (
+	"fmt"
+
+	"github.com/indece-official/semantic-version/pkg/semver/changelog"
+)
+
+// IssueCommit is a change referencing an issue
+type IssueCommit struct {
+	Hash    string `json:"hash" yaml:"hash"`
+	Type    string `json:"type,omitempty" yaml:"type,omitempty"`
+	Scope   string `json:"scope,omitempty" yaml:"scope,omitempty"`
+	Message string `json:"message" yaml:"message"`
+}
+
+// Issue is an issue referenced by the changes since the last release
+type Issue struct {
+	// Issue is the reference as written in the commit messages (e.g. '#123')
+	Issue   string `json:"issue" yaml:"issue"`
+	ID      string `json:"id" yaml:"id"`
+	Tracker string `json:"tracker" yaml:"tracker"`
+	// URL is the link to the issue, empty if the issue pattern has no url
+	URL     string         `json:"url,omitempty" yaml:"url,omitempty"`
+	Commits []*IssueCommit `json:"commits" yaml:"commits"`
+}
+
+// GetIssues returns all issues referenced by the changes since the last
+// release (the changes of GetChangelog) in order of their first reference
+//
+// ErrNoBranchConfig is returned if no branch config matches the current branch.
+func (a *Analyzer) GetIssues() ([]*Issue, error) {
+	_, branchConfig, err := a.GetCurrentBranchConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error getting branch config: %s", err)
+	}
+
+	if branchConfig == nil {
+		return nil, ErrNoBranchConfig
+	}
+
+	commits, err := a.GetCommitsSinceLastRelease(branchConfig, a.cfg.GetReleaseChannels().GetLowestRelease())
+	if err != nil {
+		return nil, fmt.Errorf("error loading commits since last release: %s", err)
+	}
+
+	issues := []*Issue{}
+	issuesByReference := map[string]*Issue{}
+
+	// Oldest commits first
+	for i := len(commits) - 1; i >= 0; i-- {
+		commit := commits[i]
+
+		for _, parsedCommit := range changelog.ParseCommitMessageWithIssuePatterns(commit.Message, commit.Hash.String(), a.cfg.IssuePatterns) {
+			for _, reference := range parsedCommit.IssueReferences {
+				issue, exists := issuesByReference[reference.Issue]
+				if !exists {
+					issue = &Issue{
+						Issue:   reference.Issue,
+						ID:      reference.ID,
+						Tracker: reference.Tracker,
+						URL:     reference.URL,
+						Commits: []*IssueCommit{},
+					}
+
+					issuesByReference[reference.Issue] = issue
+					issues = append(issues, issue)
+				}
+
+				issue.Commits = append(issue.Commits, &IssueCommit{
+					Hash:    parsedCommit.Hash,
+					Type:    parsedCommit.Type,
+					Scope:   parsedCommit.Scope,
+					Message: parsedCommit.Message,
+				})
+			}
+		}
+	}
+
+	a.logger.Debugf("Found %d issues in %d commits since last release", len(issues), len(commits))
+
+	return issues, nil
+}
@@ -0,0 +1,177 @@
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/indece-official/semantic-version/pkg/semver"
+	"github.com/indece-official/semantic-version/pkg/semver/config"
+)
+
+// ErrPromotionNotPossible is returned if a tag can't be promoted
+var ErrPromotionNotPossible = errors.New("promotion not possible")
+
+// Promotion is the promotion of a prerelease tag to a more stable release
+// channel on the same commit
+type Promotion struct {
+	// From is the promoted prerelease tag
+	From               string                `json:"from" yaml:"from"`
+	FromReleaseChannel semver.ReleaseChannel `json:"from_release_channel" yaml:"from_release_channel"`
+	// Version is the new tag
+	Version        string                `json:"version" yaml:"version"`
+	ReleaseChannel semver.ReleaseChannel `json:"release_channel" yaml:"release_channel"`
+	Commit         string                `json:"commit" yaml:"commit"`
+
+	branchConfig *config.BranchConfig
+	versionInfo  *semver.VersionInfo
+}
+
+// getReleaseTag returns the loaded tag with the name parsed with the most
+// stable release channel, nil if the tag doesn't exist or is no release
+func (a *Analyzer) getReleaseTag(name string) *semver.Tag {
+	releaseChannels := a.cfg.GetReleaseChannels()
+
+	var releaseTag *semver.Tag
+
+	for _, tags := range a.mapCommitTags {
+		for _, tag := range tags {
+			if tag.Name != name || !releaseChannels.IsRelease(tag.Version.ReleaseChannel) {
+				continue
+			}
+
+			if releaseTag == nil || releaseChannels.GetPrio(tag.Version.ReleaseChannel) > releaseChannels.GetPrio(releaseTag.Version.ReleaseChannel) {
+				releaseTag = tag
+			}
+		}
+	}
+
+	return releaseTag
+}
+
+// getPromotionBranchConfig returns the branch config of the release channel
+// a tag of releaseChannel is promoted to: the configured release channel
+// with the next higher priority counting as release if targetReleaseChannel
+// is empty
+func (a *Analyzer) getPromotionBranchConfig(releaseChannel semver.ReleaseChannel, targetReleaseChannel semver.ReleaseChannel) *config.BranchConfig {
+	releaseChannels := a.cfg.GetReleaseChannels()
+
+	var promotionBranchConfig *config.BranchConfig
+
+	for _, branchConfig := range a.getBranchConfigs() {
+		prio := releaseChannels.GetPrio(branchConfig.ReleaseChannel)
+
+		if targetReleaseChannel != semver.ReleaseChannelNone {
+			if branchConfig.ReleaseChannel == targetReleaseChannel {
+				return branchConfig
+			}
+
+			continue
+		}
+
+		if prio <= releaseChannels.GetPrio(releaseChannel) || !releaseChannels.IsRelease(branchConfig.ReleaseChannel) {
+			continue
+		}
+
+		if promotionBranchConfig == nil || prio < releaseChannels.GetPrio(promotionBranchConfig.ReleaseChannel) {
+			promotionBranchConfig = branchConfig
+		}
+	}
+
+	return promotionBranchConfig
+}
+
+// Promote computes the promotion of the prerelease tag tagName to the release
+// channel targetReleaseChannel (the next configured release channel if empty)
+//
+// The version of the tag is kept, commits are not analyzed. ErrPromotionNotPossible
+// is returned if the tag is no prerelease, the target release channel isn't
+// more stable or a tag with a higher version of at least the target release
+// channel already exists.
+func (a *Analyzer) Promote(tagName string, targetReleaseChannel semver.ReleaseChannel) (*Promotion, error) {
+	releaseChannels := a.cfg.GetReleaseChannels()
+
+	tag := a.getReleaseTag(tagName)
+	if tag == nil {
+		return nil, fmt.Errorf("%w: %s is no release tag", ErrPromotionNotPossible, tagName)
+	}
+
+	releaseChannel := tag.Version.ReleaseChannel
+	if releaseChannels.IsFinal(releaseChannel) {
+		return nil, fmt.Errorf("%w: %s is a final release", ErrPromotionNotPossible, tagName)
+	}
+
+	if targetReleaseChannel != semver.ReleaseChannelNone && releaseChannels.GetPrio(targetReleaseChannel) <= releaseChannels.GetPrio(releaseChannel) {
+		return nil, fmt.Errorf("%w: release channel %s is not more stable than %s", ErrPromotionNotPossible, targetReleaseChannel, releaseChannel)
+	}
+
+	branchConfig := a.getPromotionBranchConfig(releaseChannel, targetReleaseChannel)
+	if branchConfig == nil {
+		return nil, fmt.Errorf("%w: no branch config with a release channel to promote %s to", ErrPromotionNotPossible, releaseChannel)
+	}
+
+	versionInfo := &semver.VersionInfo{
+		Major:           tag.Version.Major,
+		Minor:           tag.Version.Minor,
+		Patch:           tag.Version.Patch,
+		Build:           0,
+		Year:            tag.Version.Year,
+		Month:           tag.Version.Month,
+		Week:            tag.Version.Week,
+		Day:             tag.Version.Day,
+		Branch:          tag.Version.Branch,
+		Commit:          tag.Commit,
+		ShortCommit:     tag.Commit[:10],
+		ReleaseChannel:  branchConfig.ReleaseChannel,
+		Scheme:          branchConfig.GetVersionScheme(),
+		ReleaseChannels: a.cfg.GetReleaseChannels(),
+	}
+
+	versionPattern := branchConfig.GetVersionPattern()
+
+	newTagName, err := versionPattern.GenerateUnique(versionInfo, a.mapTags, false)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s already exists", ErrPromotionNotPossible, versionPattern.Generate(versionInfo))
+	}
+
+	newTag := semver.NewTag(newTagName, versionInfo, tag.Commit)
+
+	for _, existingTag := range a.getReleaseTags(branchConfig.ReleaseChannel) {
+		if existingTag.Compare(newTag) >= 0 {
+			return nil, fmt.Errorf("%w: higher version %s already exists", ErrPromotionNotPossible, existingTag.Name)
+		}
+	}
+
+	a.logger.Debugf("Promoting %s (%s) to %s (%s)", tagName, releaseChannel, newTagName, branchConfig.ReleaseChannel)
+
+	return &Promotion{
+		From:               tagName,
+		FromReleaseChannel: releaseChannel,
+		Version:            newTagName,
+		ReleaseChannel:     branchConfig.ReleaseChannel,
+		Commit:             tag.Commit,
+		branchConfig:       branchConfig,
+		versionInfo:        versionInfo,
+	}, nil
+}
+
+// RegeneratePromotion generates a new tag for a promotion which is neither
+// used by a local tag nor contained in usedTags (e.g. tags existing on a
+// remote)
+func (a *Analyzer) RegeneratePromotion(promotion *Promotion, usedTags map[string]bool) error {
+	allUsedTags := map[string]bool{}
+	for tagName, used := range a.mapTags {
+		allUsedTags[tagName] = used
+	}
+	for tagName, used := range usedTags {
+		allUsedTags[tagName] = allUsedTags[tagName] || used
+	}
+
+	newTag, err := promotion.branchConfig.GetVersionPattern().GenerateUnique(promotion.versionInfo, allUsedTags, false)
+	if err != nil {
+		return fmt.Errorf("error generating version: %s", err)
+	}
+
+	promotion.Version = newTag
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/indece-official/semantic-version/pkg/semver"
+)
+
+// ErrNoVersionTag is returned if HEAD has no version tag of the current branch
+var ErrNoVersionTag = errors.New("no version tag on HEAD")
+
+// Publication is a version tag with its changelog to publish as release on a
+// hosting service
+type Publication struct {
+	Tag string `json:"tag" yaml:"tag"`
+	// PreviousTag is the tag of the previous release, empty for the first release
+	PreviousTag    string                `json:"previous_tag" yaml:"previous_tag"`
+	ReleaseChannel semver.ReleaseChannel `json:"release_channel" yaml:"release_channel"`
+	// Prerelease is true if the release channel of the branch is lower than FINAL
+	Prerelease bool   `json:"prerelease" yaml:"prerelease"`
+	Changelog  string `json:"changelog" yaml:"changelog"`
+}
+
+// GetPublication returns the version tag of the current branch on HEAD (e.g.
+// created by Release) with the changelog of its changes
+//
+// ErrNoBranchConfig is returned if no branch config matches the current branch,
+// ErrNoVersionTag if HEAD has no tag matching the branch's version pattern.
+func (a *Analyzer) GetPublication() (*Publication, error) {
+	_, branchConfig, err := a.GetCurrentBranchConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error getting branch config: %s", err)
+	}
+
+	if branchConfig == nil {
+		return nil, ErrNoBranchConfig
+	}
+
+	// Find the highest tag on HEAD matching the branch's version pattern
+	var headTag *semver.Tag
+
+	headHash := a.headCommit.Hash.String()
+	for _, tag := range a.mapCommitTags[headHash] {
+		versionInfo := branchConfig.GetVersionPattern().Parse(tag.Name)
+		if versionInfo == nil {
+			continue
+		}
+
+		versionTag := semver.NewTag(tag.Name, versionInfo, headHash)
+		if headTag == nil || versionTag.Compare(headTag) > 0 {
+			headTag = versionTag
+		}
+	}
+
+	if headTag == nil {
+		return nil, ErrNoVersionTag
+	}
+
+	publication := &Publication{
+		Tag:            headTag.Name,
+		ReleaseChannel: branchConfig.ReleaseChannel,
+		Prerelease:     !a.cfg.GetReleaseChannels().IsFinal(branchConfig.ReleaseChannel),
+	}
+
+	minReleaseChannel := branchConfig.ReleaseChannel
+	if !a.cfg.GetReleaseChannels().IsRelease(minReleaseChannel) {
+		minReleaseChannel = a.cfg.GetReleaseChannels().GetLowestRelease()
+	}
+
+	releases, err := a.GetReleases(minReleaseChannel)
+	if err != nil {
+		return nil, err
+	}
+
+	commitParser := a.newCommitParser()
+	date := a.getDate()
+	found := false
+
+	for _, release := range releases {
+		if release.Tag.Name != headTag.Name {
+			continue
+		}
+
+		if release.PreviousTag != nil {
+			publication.PreviousTag = release.PreviousTag.Name
+		}
+
+		commitParser.Parse(release.Commits)
+		date = release.Date
+		found = true
+
+		break
+	}
+
+	// Tags of branches without release channel are no releases, their
+	// changelog contains all changes since the last release
+	if !found {
+		highestTag, err := a.GetHighestFinalReleaseTag()
+		if err != nil {
+			return nil, fmt.Errorf("error getting highest final release: %s", err)
+		}
+
+		if highestTag != nil {
+			publication.PreviousTag = highestTag.Name
+		}
+
+		commits, err := a.GetCommitsSinceLastRelease(branchConfig, a.cfg.GetReleaseChannels().GetLowestRelease())
+		if err != nil {
+			return nil, fmt.Errorf("error loading commits since last release: %s", err)
+		}
+
+		commitParser.Parse(commits)
+	}
+
+	publication.Changelog, err = a.renderChangelog(commitParser, 2, publication.Tag, publication.PreviousTag, headTag.Version, date)
+	if err != nil {
+		return nil, err
+	}
+
+	return publication, nil
+}
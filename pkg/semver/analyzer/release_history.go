@@ -0,0 +1,469 @@
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/indece-official/semantic-version/pkg/semver"
+	"github.com/indece-official/semantic-version/pkg/semver/changelog"
+)
+
+// Release is a released version (tag) with all commits since the previous release
+type Release struct {
+	Tag *semver.Tag
+	// PreviousTag is the highest release tag contained in the history of Tag,
+	// nil for the first release
+	PreviousTag *semver.Tag
+	Date        time.Time
+	Commits     []*object.Commit
+}
+
+// getTagDate returns the date of an annotated tag or the commit date of a
+// lightweight tag
+func (a *Analyzer) getTagDate(tag *semver.Tag) (time.Time, error) {
+	ref, err := a.repo.Tag(tag.Name)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("can't load tag %s: %s", tag.Name, err)
+	}
+
+	tagObject, err := a.repo.TagObject(ref.Hash())
+	if err == nil {
+		return tagObject.Tagger.When, nil
+	}
+
+	if !errors.Is(err, plumbing.ErrObjectNotFound) {
+		return time.Time{}, fmt.Errorf("can't load tag object %s: %s", tag.Name, err)
+	}
+
+	commit, err := a.repo.CommitObject(plumbing.NewHash(tag.Commit))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("can't load commit of tag %s: %s", tag.Name, err)
+	}
+
+	return commit.Committer.When, nil
+}
+
+// getReleaseTags returns all release tags with a release channel of at least
+// minReleaseChannel sorted ascending by version
+func (a *Analyzer) getReleaseTags(minReleaseChannel semver.ReleaseChannel) []*semver.Tag {
+	releaseChannels := a.cfg.GetReleaseChannels()
+
+	releaseTags := []*semver.Tag{}
+	seenTags := map[string]bool{}
+
+	for _, tags := range a.mapCommitTags {
+		for _, tag := range tags {
+			if seenTags[tag.Name] ||
+				!releaseChannels.IsRelease(tag.Version.ReleaseChannel) ||
+				releaseChannels.GetPrio(tag.Version.ReleaseChannel) < releaseChannels.GetPrio(minReleaseChannel) {
+				continue
+			}
+
+			releaseTags = append(releaseTags, tag)
+			seenTags[tag.Name] = true
+		}
+	}
+
+	sort.Slice(releaseTags, func(i, j int) bool {
+		if c := releaseTags[i].Compare(releaseTags[j]); c != 0 {
+			return c < 0
+		}
+
+		return releaseTags[i].Name < releaseTags[j].Name
+	})
+
+	return releaseTags
+}
+
+// collectAllCommits returns all commits in the history of commit which are
+// not contained in seen and adds them to seen
+func collectAllCommits(commit *object.Commit, seen map[plumbing.Hash]bool) ([]*object.Commit, error) {
+	commits := []*object.Commit{}
+
+	commitIter := object.NewCommitPreorderIter(commit, seen, []plumbing.Hash{})
+	for {
+		c, err := commitIter.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return nil, fmt.Errorf("can't iterate commits: %s", err)
+		}
+
+		seen[c.Hash] = true
+		commits = append(commits, c)
+	}
+
+	return commits, nil
+}
+
+// collectCommits returns all commits in the history of commit which are not
+// contained in seen and change files of the analyzed project, all commits of
+// the history are added to seen
+func (a *Analyzer) collectCommits(commit *object.Commit, seen map[plumbing.Hash]bool) ([]*object.Commit, error) {
+	commits, err := collectAllCommits(commit, seen)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.filterCommits(commits)
+}
+
+// GetReleases returns all releases with a release channel of at least
+// minReleaseChannel sorted ascending by version
+//
+// The commits of a release are all commits in the history of its tag, which
+// are not contained in the history of a lower release.
+func (a *Analyzer) GetReleases(minReleaseChannel semver.ReleaseChannel) ([]*Release, error) {
+	releases := []*Release{}
+	releaseTags := a.getReleaseTags(minReleaseChannel)
+	seen := map[plumbing.Hash]bool{}
+
+	for i, tag := range releaseTags {
+		commit, err := a.repo.CommitObject(plumbing.NewHash(tag.Commit))
+		if err != nil {
+			return nil, fmt.Errorf("can't load commit of tag %s: %s", tag.Name, err)
+		}
+
+		date, err := a.getTagDate(tag)
+		if err != nil {
+			return nil, err
+		}
+
+		release := &Release{
+			Tag:     tag,
+			Date:    date,
+			Commits: []*object.Commit{},
+		}
+
+		for j := i - 1; j >= 0; j-- {
+			if releaseTags[j].Commit == tag.Commit {
+				release.PreviousTag = releaseTags[j]
+
+				break
+			}
+
+			previousCommit, err := a.repo.CommitObject(plumbing.NewHash(releaseTags[j].Commit))
+			if err != nil {
+				return nil, fmt.Errorf("can't load commit of tag %s: %s", releaseTags[j].Name, err)
+			}
+
+			isAncestor, err := previousCommit.IsAncestor(commit)
+			if err != nil {
+				return nil, fmt.Errorf("can't check history of tag %s: %s", tag.Name, err)
+			}
+
+			if isAncestor {
+				release.PreviousTag = releaseTags[j]
+
+				break
+			}
+		}
+
+		release.Commits, err = a.collectCommits(commit, seen)
+		if err != nil {
+			return nil, err
+		}
+
+		a.logger.Debugf("Found release %s with %d commits", tag.Name, len(release.Commits))
+
+		releases = append(releases, release)
+	}
+
+	return releases, nil
+}
+
+// renderChangelog renders the changelog of the parsed commits using the
+// configured changelog template (default markdown changelog if not set)
+func (a *Analyzer) renderChangelog(commitParser *changelog.CommitParser, headingLevel int, version string, previousVersion string, versionInfo *semver.VersionInfo, date time.Time) (string, error) {
+	if a.cfg.Changelog.Template == "" {
+		return commitParser.GenerateChangelogWithHeadingLevel(headingLevel), nil
+	}
+
+	tpl, err := changelog.LoadChangelogTemplate(a.cfg.Changelog.Template)
+	if err != nil {
+		return "", err
+	}
+
+	data := commitParser.GetChangelogData(headingLevel)
+	data.Version = version
+	data.PreviousVersion = previousVersion
+	data.VersionInfo = versionInfo
+	data.Date = date
+	data.CompareURL = changelog.FormatCompareURL(a.cfg.Changelog.CompareURL, previousVersion, version)
+
+	return tpl.Render(data)
+}
+
+func (a *Analyzer) newChangelogSection(version string, previousVersion string, versionInfo *semver.VersionInfo, date time.Time, commits []*object.Commit) (*changelog.ChangelogSection, error) {
+	commitParser := a.newCommitParser()
+	commitParser.Parse(commits)
+
+	body, err := a.renderChangelog(commitParser, 3, version, previousVersion, versionInfo, date)
+	if err != nil {
+		return nil, err
+	}
+
+	return &changelog.ChangelogSection{
+		Version:         version,
+		PreviousVersion: previousVersion,
+		Date:            date,
+		CompareURL:      changelog.FormatCompareURL(a.cfg.Changelog.CompareURL, previousVersion, version),
+		Body:            body,
+	}, nil
+}
+
+// GetChangelogSection returns the changelog section for the new version of
+// the current branch (see ComputeVersion) released at date
+func (a *Analyzer) GetChangelogSection(date time.Time) (*changelog.ChangelogSection, error) {
+	result, err := a.ComputeVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	commits, err := a.GetCommitsSinceLastRelease(result.branchConfig, a.cfg.GetReleaseChannels().GetLowestRelease())
+	if err != nil {
+		return nil, fmt.Errorf("error loading commits since last release: %s", err)
+	}
+
+	return a.newChangelogSection(result.Version, result.PreviousVersion, result.versionInfo, date, commits)
+}
+
+// GetChangelogHistory returns the changelog sections of all past releases
+// with at least the release channel of the current branch (newest first)
+func (a *Analyzer) GetChangelogHistory() ([]*changelog.ChangelogSection, error) {
+	_, branchConfig, err := a.GetCurrentBranchConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error getting branch config: %s", err)
+	}
+
+	if branchConfig == nil {
+		return nil, ErrNoBranchConfig
+	}
+
+	minReleaseChannel := branchConfig.ReleaseChannel
+	if !a.cfg.GetReleaseChannels().IsRelease(minReleaseChannel) {
+		minReleaseChannel = a.cfg.GetReleaseChannels().GetLowestRelease()
+	}
+
+	releases, err := a.GetReleases(minReleaseChannel)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := []*changelog.ChangelogSection{}
+	for i := len(releases) - 1; i >= 0; i-- {
+		release := releases[i]
+
+		previousVersion := ""
+		if release.PreviousTag != nil {
+			previousVersion = release.PreviousTag.Name
+		}
+
+		section, err := a.newChangelogSection(release.Tag.Name, previousVersion, release.Tag.Version, release.Date, release.Commits)
+		if err != nil {
+			return nil, err
+		}
+
+		sections = append(sections, section)
+	}
+
+	return sections, nil
+}
+
+// UnreleasedVersion is the version of the changelog section containing the
+// changes after the last release of a range
+const UnreleasedVersion = "Unreleased"
+
+func (a *Analyzer) resolveCommit(revision string) (*object.Commit, error) {
+	hash, err := a.repo.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		return nil, fmt.Errorf("can't resolve revision %s: %s", revision, err)
+	}
+
+	commit, err := a.repo.CommitObject(*hash)
+	if err != nil {
+		return nil, fmt.Errorf("can't load commit of revision %s: %s", revision, err)
+	}
+
+	return commit, nil
+}
+
+// getReleasesBetween splits all commits in the history of the revision to,
+// which are not contained in the history of the revision from, into one
+// release per final release tag (sorted ascending)
+//
+// Commits after the last release tag are returned as release without tag.
+func (a *Analyzer) getReleasesBetween(from string, to string) ([]*Release, error) {
+	toCommit, err := a.resolveCommit(to)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[plumbing.Hash]bool{}
+
+	if from != "" {
+		fromCommit, err := a.resolveCommit(from)
+		if err != nil {
+			return nil, err
+		}
+
+		// Exclude the history of from
+		_, err = collectAllCommits(fromCommit, seen)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	allCommits, err := collectAllCommits(toCommit, map[plumbing.Hash]bool{})
+	if err != nil {
+		return nil, err
+	}
+
+	inRange := map[string]bool{}
+	for _, commit := range allCommits {
+		if !seen[commit.Hash] {
+			inRange[commit.Hash.String()] = true
+		}
+	}
+
+	releases := []*Release{}
+	var previousTag *semver.Tag
+
+	for _, tag := range a.getReleaseTags(semver.ReleaseChannelFinal) {
+		if !inRange[tag.Commit] {
+			continue
+		}
+
+		commit, err := a.repo.CommitObject(plumbing.NewHash(tag.Commit))
+		if err != nil {
+			return nil, fmt.Errorf("can't load commit of tag %s: %s", tag.Name, err)
+		}
+
+		date, err := a.getTagDate(tag)
+		if err != nil {
+			return nil, err
+		}
+
+		release := &Release{
+			Tag:         tag,
+			PreviousTag: previousTag,
+			Date:        date,
+		}
+
+		release.Commits, err = a.collectCommits(commit, seen)
+		if err != nil {
+			return nil, err
+		}
+
+		a.logger.Debugf("Found release %s with %d commits in range", tag.Name, len(release.Commits))
+
+		releases = append(releases, release)
+		previousTag = tag
+	}
+
+	commits, err := a.collectCommits(toCommit, seen)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(commits) > 0 || len(releases) == 0 {
+		releases = append(releases, &Release{
+			PreviousTag: previousTag,
+			Commits:     commits,
+		})
+	}
+
+	return releases, nil
+}
+
+// newRangeChangelogSections returns the changelog sections of the releases
+// of a range (see getReleasesBetween) newest first
+func (a *Analyzer) newRangeChangelogSections(releases []*Release, from string, to string) ([]*changelog.ChangelogSection, error) {
+	sections := []*changelog.ChangelogSection{}
+
+	for _, release := range releases {
+		previousVersion := from
+		if release.PreviousTag != nil {
+			previousVersion = release.PreviousTag.Name
+		}
+
+		if release.Tag == nil {
+			section, err := a.newChangelogSection(UnreleasedVersion, previousVersion, nil, time.Time{}, release.Commits)
+			if err != nil {
+				return nil, err
+			}
+
+			section.CompareURL = changelog.FormatCompareURL(a.cfg.Changelog.CompareURL, previousVersion, to)
+
+			sections = append([]*changelog.ChangelogSection{section}, sections...)
+
+			continue
+		}
+
+		section, err := a.newChangelogSection(release.Tag.Name, previousVersion, release.Tag.Version, release.Date, release.Commits)
+		if err != nil {
+			return nil, err
+		}
+
+		sections = append([]*changelog.ChangelogSection{section}, sections...)
+	}
+
+	return sections, nil
+}
+
+// GetChangelogSectionsBetween returns the changelog sections of all commits
+// in the history of the revision to, which are not contained in the history
+// of the revision from (newest first)
+//
+// Revisions can be tags, branches or commit hashes, an empty from includes the
+// whole history of to. The commits are split into one section per final
+// release tag in the range, commits after the last release tag are returned
+// in a section with the version UnreleasedVersion.
+func (a *Analyzer) GetChangelogSectionsBetween(from string, to string) ([]*changelog.ChangelogSection, error) {
+	releases, err := a.getReleasesBetween(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.newRangeChangelogSections(releases, from, to)
+}
+
+// GetChangelogBetween generates the changelog of all commits in the history
+// of the revision to, which are not contained in the history of the revision
+// from (see GetChangelogSectionsBetween)
+//
+// If the range contains release tags, the changelog consists of one section
+// per release.
+func (a *Analyzer) GetChangelogBetween(from string, to string) (string, error) {
+	releases, err := a.getReleasesBetween(from, to)
+	if err != nil {
+		return "", err
+	}
+
+	if len(releases) == 1 && releases[0].Tag == nil {
+		commitParser := a.newCommitParser()
+		commitParser.Parse(releases[0].Commits)
+
+		return a.renderChangelog(commitParser, 1, to, from, nil, time.Time{})
+	}
+
+	sections, err := a.newRangeChangelogSections(releases, from, to)
+	if err != nil {
+		return "", err
+	}
+
+	parts := []string{}
+	for _, section := range sections {
+		parts = append(parts, section.String())
+	}
+
+	return strings.Join(parts, "\n"), nil
+}
@@ -0,0 +1,51 @@
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/indece-official/semantic-version/pkg/semver/config"
+)
+
+// GetReleasePlan computes the versions of all projects ordered topologically
+// by their dependencies (dependencies first)
+//
+// The project of options is ignored, projects without a branch config for
+// the current branch are skipped.
+func GetReleasePlan(repo *git.Repository, cfg *config.Config, options *Options) ([]*VersionResult, error) {
+	if options == nil {
+		options = &Options{}
+	}
+
+	results := map[string]*VersionResult{}
+	plan := []*VersionResult{}
+
+	for _, project := range cfg.GetProjectOrder() {
+		projectOptions := *options
+		projectOptions.Project = project.Name
+
+		a := NewAnalyzer(repo, cfg, &projectOptions)
+
+		err := a.Load()
+		if err != nil {
+			return nil, fmt.Errorf("error loading project %s: %s", project.Name, err)
+		}
+
+		result, exists := results[project.Name]
+		if !exists {
+			result, err = a.computeVersion(results)
+			if err != nil && !errors.Is(err, ErrNoBranchConfig) {
+				return nil, fmt.Errorf("error computing version of project %s: %s", project.Name, err)
+			}
+
+			results[project.Name] = result
+		}
+
+		if result != nil {
+			plan = append(plan, result)
+		}
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,91 @@
+package analyzer
+
+import (
+	"github.com/indece-official/semantic-version/pkg/semver"
+	"github.com/indece-official/semantic-version/pkg/semver/changelog"
+	"github.com/indece-official/semantic-version/pkg/semver/config"
+)
+
+// VersionResultReason is a change which caused the version increment
+type VersionResultReason struct {
+	Hash      string                       `json:"hash" yaml:"hash"`
+	Type      string                       `json:"type,omitempty" yaml:"type,omitempty"`
+	Scope     string                       `json:"scope,omitempty" yaml:"scope,omitempty"`
+	Breaking  bool                         `json:"breaking" yaml:"breaking"`
+	Message   string                       `json:"message" yaml:"message"`
+	Increment semver.VersionIncrementLevel `json:"increment" yaml:"increment"`
+}
+
+// VersionResultDependency is a changed dependency of a project which caused
+// a version increment
+type VersionResultDependency struct {
+	Project string `json:"project" yaml:"project"`
+	// Version is the new version of the dependency
+	Version string `json:"version" yaml:"version"`
+	// Increment is the version increment of the project caused by the dependency
+	Increment semver.VersionIncrementLevel `json:"increment" yaml:"increment"`
+}
+
+// VersionResult contains the computed version and all details of its computation
+type VersionResult struct {
+	// Project is the name of the analyzed project, empty for the whole repository
+	Project string `json:"project,omitempty" yaml:"project,omitempty"`
+	// Version is the generated version tag
+	Version string `json:"version" yaml:"version"`
+	// PreviousVersion is the tag of the release the version is based on,
+	// empty if there is no previous release
+	PreviousVersion string                       `json:"previous_version" yaml:"previous_version"`
+	Increment       semver.VersionIncrementLevel `json:"increment" yaml:"increment"`
+	Reasons         []*VersionResultReason       `json:"reasons" yaml:"reasons"`
+	// Dependencies contains all changed dependencies causing a version increment
+	Dependencies   []*VersionResultDependency `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
+	Branch         string                     `json:"branch" yaml:"branch"`
+	BranchPattern  string                     `json:"branch_pattern" yaml:"branch_pattern"`
+	VersionPattern string                     `json:"version_pattern" yaml:"version_pattern"`
+	ReleaseChannel semver.ReleaseChannel      `json:"release_channel" yaml:"release_channel"`
+	Major          int                        `json:"major" yaml:"major"`
+	Minor          int                        `json:"minor" yaml:"minor"`
+	Patch          int                        `json:"patch" yaml:"patch"`
+	Build          int                        `json:"build" yaml:"build"`
+	Commit         string                     `json:"commit" yaml:"commit"`
+	ShortCommit    string                     `json:"short_commit" yaml:"short_commit"`
+
+	branchConfig *config.BranchConfig
+	versionInfo  *semver.VersionInfo
+}
+
+// HasChanges returns true if the project changed since the previous release
+// (commits or dependencies) or if there is no previous release
+func (r *VersionResult) HasChanges() bool {
+	return r.PreviousVersion == "" || len(r.Reasons) > 0 || len(r.Dependencies) > 0
+}
+
+// KeepsPreviousVersion returns true if the previous release is kept, because all
+// changes since it have commit types with increment NONE
+func (r *VersionResult) KeepsPreviousVersion() bool {
+	return r.Increment == semver.VersionIncrementLevelNone && r.PreviousVersion != "" && r.Version == r.PreviousVersion
+}
+
+// GetVersionInfo returns the computed version info
+func (r *VersionResult) GetVersionInfo() *semver.VersionInfo {
+	return r.versionInfo
+}
+
+func newVersionResultDependency(result *VersionResult, level semver.VersionIncrementLevel) *VersionResultDependency {
+	return &VersionResultDependency{
+		Project:   result.Project,
+		Version:   result.Version,
+		Increment: level,
+	}
+}
+
+func newVersionResultReason(parsedCommit *changelog.ParsedCommit) *VersionResultReason {
+	return &VersionResultReason{
+		Hash:      parsedCommit.Hash,
+		Type:      parsedCommit.Type,
+		Scope:     parsedCommit.Scope,
+		Breaking:  parsedCommit.IsBreaking(),
+		Message:   parsedCommit.Message,
+		Increment: parsedCommit.GetIncrementLevel(),
+	}
+}
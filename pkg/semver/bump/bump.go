@@ -0,0 +1,193 @@
+package bump
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"github.com/indece-official/semantic-version/pkg/semver"
+	"github.com/indece-official/semantic-version/pkg/semver/config"
+	"github.com/indece-official/semantic-version/pkg/semver/pattern"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// ErrVersionNotFound is returned by updaters if the configured path or
+// pattern was not found in the file
+var ErrVersionNotFound = errors.New("version not found")
+
+// Updater replaces the version in the content of a file
+type Updater interface {
+	Update(content []byte, version string) ([]byte, error)
+}
+
+// UpdaterFactory creates an updater for a bump file config
+type UpdaterFactory func(cfg *config.BumpFileConfig) (Updater, error)
+
+var updaterFactories = map[config.BumpFileType]UpdaterFactory{
+	config.BumpFileTypeJSON:  NewJSONUpdater,
+	config.BumpFileTypeYAML:  NewYAMLUpdater,
+	config.BumpFileTypeXML:   NewXMLUpdater,
+	config.BumpFileTypeTOML:  NewTOMLUpdater,
+	config.BumpFileTypeRegex: NewRegexUpdater,
+}
+
+// RegisterUpdater registers the updater factory for a file type, replacing
+// built-in updaters of the same type
+func RegisterUpdater(fileType config.BumpFileType, factory UpdaterFactory) {
+	updaterFactories[fileType] = factory
+}
+
+// NewUpdater creates the updater for a bump file config
+func NewUpdater(cfg *config.BumpFileConfig) (Updater, error) {
+	factory, ok := updaterFactories[cfg.Type]
+	if !ok {
+		return nil, fmt.Errorf("no updater for file type %s", cfg.Type)
+	}
+
+	return factory(cfg)
+}
+
+// FileChange is the change of a file by the bumper
+type FileChange struct {
+	Filename   string
+	Version    string
+	OldContent []byte
+	NewContent []byte
+}
+
+// IsChanged returns true if the content of the file changed
+func (c *FileChange) IsChanged() bool {
+	return string(c.OldContent) != string(c.NewContent)
+}
+
+// Diff returns the unified diff of the change
+func (c *FileChange) Diff() (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(c.OldContent),
+		B:        splitLines(c.NewContent),
+		FromFile: "a/" + c.Filename,
+		ToFile:   "b/" + c.Filename,
+		Context:  3,
+	})
+}
+
+// FormatVersion returns the version written to a file, either generated by
+// the file's version pattern or the version tag without prefix
+func FormatVersion(cfg *config.BumpFileConfig, tag string, versionInfo *semver.VersionInfo) (string, error) {
+	if cfg.VersionPattern == "" {
+		return strings.TrimLeftFunc(tag, func(r rune) bool {
+			return r < '0' || r > '9'
+		}), nil
+	}
+
+	versionPattern, err := pattern.NewSchemeVersionPattern(cfg.VersionPattern, versionInfo.ReleaseChannel, versionInfo.GetScheme(), versionInfo.ReleaseChannels)
+	if err != nil {
+		return "", fmt.Errorf("invalid version pattern of file %s: %s", cfg.File, err)
+	}
+
+	return versionPattern.Generate(versionInfo), nil
+}
+
+// Bumper writes a version to all configured files
+type Bumper struct {
+	files   []*config.BumpFileConfig
+	baseDir string
+	logger  semver.Logger
+}
+
+// Bump updates the version in all files, files are only written if dryRun
+// is false and the changes of all files could be computed (no file is written
+// if one of them fails)
+func (b *Bumper) Bump(tag string, versionInfo *semver.VersionInfo, dryRun bool) ([]*FileChange, error) {
+	changes := []*FileChange{}
+
+	for _, file := range b.files {
+		filename := file.File
+		if b.baseDir != "" && !path.IsAbs(filename) {
+			filename = path.Join(b.baseDir, filename)
+		}
+
+		updater, err := NewUpdater(file)
+		if err != nil {
+			return nil, err
+		}
+
+		version, err := FormatVersion(file, tag, versionInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %s", filename, err)
+		}
+
+		newContent, err := updater.Update(content, version)
+		if err != nil {
+			return nil, fmt.Errorf("error updating %s: %w", filename, err)
+		}
+
+		change := &FileChange{
+			Filename:   filename,
+			Version:    version,
+			OldContent: content,
+			NewContent: newContent,
+		}
+
+		b.logger.Debugf("Updating version in %s to %s (changed: %v)", filename, version, change.IsChanged())
+
+		changes = append(changes, change)
+	}
+
+	if dryRun {
+		return changes, nil
+	}
+
+	for _, change := range changes {
+		if !change.IsChanged() {
+			continue
+		}
+
+		err := ioutil.WriteFile(change.Filename, change.NewContent, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("error writing %s: %s", change.Filename, err)
+		}
+	}
+
+	return changes, nil
+}
+
+// NewBumper creates a new bumper for the files, relative paths are resolved
+// against baseDir
+func NewBumper(files []*config.BumpFileConfig, baseDir string) *Bumper {
+	return &Bumper{
+		files:   files,
+		baseDir: baseDir,
+		logger:  semver.GetDefaultLogger(),
+	}
+}
+
+// SetLogger sets the logger receiving the diagnostics of the bumper
+func (b *Bumper) SetLogger(logger semver.Logger) {
+	b.logger = semver.GetLogger(logger)
+}
+
+func splitLines(content []byte) []string {
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+func replaceRange(content []byte, start int, end int, value string) []byte {
+	result := make([]byte, 0, len(content)-(end-start)+len(value))
+	result = append(result, content[:start]...)
+	result = append(result, value...)
+	result = append(result, content[end:]...)
+
+	return result
+}
@@ -0,0 +1,135 @@
+package bump
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/indece-official/semantic-version/pkg/semver/config"
+)
+
+// JSONUpdater replaces a string value in a JSON file in place, keeping the
+// formatting of the file
+type JSONUpdater struct {
+	path []string
+}
+
+type jsonFrame struct {
+	isObject  bool
+	expectKey bool
+	key       string
+	index     int
+}
+
+func (f *jsonFrame) element() string {
+	if f.isObject {
+		return f.key
+	}
+
+	return strconv.Itoa(f.index)
+}
+
+func (u *JSONUpdater) matches(stack []*jsonFrame) bool {
+	if len(stack) != len(u.path) {
+		return false
+	}
+
+	for i, frame := range stack {
+		if frame.element() != u.path[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Update implements Updater
+func (u *JSONUpdater) Update(content []byte, version string) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	stack := []*jsonFrame{}
+
+	valueDone := func() {
+		if len(stack) == 0 {
+			return
+		}
+
+		top := stack[len(stack)-1]
+		if top.isObject {
+			top.expectKey = true
+		} else {
+			top.index++
+		}
+	}
+
+	for {
+		offset := decoder.InputOffset()
+
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error parsing json: %s", err)
+		}
+
+		if len(stack) > 0 && stack[len(stack)-1].isObject && stack[len(stack)-1].expectKey {
+			key, ok := token.(string)
+			if !ok {
+				// End of object
+				stack = stack[:len(stack)-1]
+				valueDone()
+				continue
+			}
+
+			stack[len(stack)-1].key = key
+			stack[len(stack)-1].expectKey = false
+			continue
+		}
+
+		switch t := token.(type) {
+		case json.Delim:
+			if t == '{' || t == '[' {
+				stack = append(stack, &jsonFrame{
+					isObject:  t == '{',
+					expectKey: t == '{',
+				})
+				continue
+			}
+
+			stack = stack[:len(stack)-1]
+			valueDone()
+		default:
+			if !u.matches(stack) {
+				valueDone()
+				continue
+			}
+
+			if _, ok := t.(string); !ok {
+				return nil, fmt.Errorf("value at %s is not a string", strings.Join(u.path, "."))
+			}
+
+			start := offset + int64(bytes.IndexByte(content[offset:], '"'))
+			end := decoder.InputOffset()
+
+			value, err := json.Marshal(version)
+			if err != nil {
+				return nil, err
+			}
+
+			return replaceRange(content, int(start), int(end), string(value)), nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w at %s", ErrVersionNotFound, strings.Join(u.path, "."))
+}
+
+// NewJSONUpdater creates a new updater for JSON files, the path contains the
+// keys (or array indices) separated by '.'
+func NewJSONUpdater(cfg *config.BumpFileConfig) (Updater, error) {
+	return &JSONUpdater{
+		path: strings.Split(cfg.Path, "."),
+	}, nil
+}
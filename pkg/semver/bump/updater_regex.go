@@ -0,0 +1,47 @@
+package bump
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/indece-official/semantic-version/pkg/semver/config"
+)
+
+// RegexUpdater replaces the group named 'version' (or the first group) of
+// the first match of a regular expression
+type RegexUpdater struct {
+	exp   *regexp.Regexp
+	group int
+}
+
+// Update implements Updater
+func (u *RegexUpdater) Update(content []byte, version string) ([]byte, error) {
+	loc := u.exp.FindSubmatchIndex(content)
+	if loc == nil || loc[2*u.group] < 0 {
+		return nil, fmt.Errorf("%w matching %s", ErrVersionNotFound, u.exp.String())
+	}
+
+	return replaceRange(content, loc[2*u.group], loc[2*u.group+1], version), nil
+}
+
+// NewRegexUpdater creates a new updater for arbitrary text files
+func NewRegexUpdater(cfg *config.BumpFileConfig) (Updater, error) {
+	exp, err := regexp.Compile(cfg.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern of file %s: %s", cfg.File, err)
+	}
+
+	if exp.NumSubexp() == 0 {
+		return nil, fmt.Errorf("pattern of file %s contains no group", cfg.File)
+	}
+
+	group := exp.SubexpIndex("version")
+	if group < 0 {
+		group = 1
+	}
+
+	return &RegexUpdater{
+		exp:   exp,
+		group: group,
+	}, nil
+}
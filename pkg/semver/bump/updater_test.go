@@ -0,0 +1,172 @@
+package bump
+
+import (
+	"errors"
+	"io/ioutil"
+	"path"
+	"testing"
+
+	"github.com/indece-official/semantic-version/pkg/semver"
+	"github.com/indece-official/semantic-version/pkg/semver/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func testUpdate(t *testing.T, cfg *config.BumpFileConfig, content string, expected string) {
+	updater, err := NewUpdater(cfg)
+	assert.NoError(t, err)
+
+	result, err := updater.Update([]byte(content), "1.3.0")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, string(result))
+}
+
+func TestJSONUpdater(t *testing.T) {
+	cfg := &config.BumpFileConfig{Type: config.BumpFileTypeJSON, Path: "version"}
+
+	testUpdate(t, cfg,
+		"{\n  \"name\": \"test\",\n  \"deps\": {\"version\": \"0.1.0\"},\n  \"version\" : \"1.2.0\",\n  \"list\": [1, 2]\n}\n",
+		"{\n  \"name\": \"test\",\n  \"deps\": {\"version\": \"0.1.0\"},\n  \"version\" : \"1.3.0\",\n  \"list\": [1, 2]\n}\n")
+
+	cfg = &config.BumpFileConfig{Type: config.BumpFileTypeJSON, Path: "packages.1.version"}
+
+	testUpdate(t, cfg,
+		`{"packages": [{"version": "1.0.0"}, {"version": "1.2.0"}]}`,
+		`{"packages": [{"version": "1.0.0"}, {"version": "1.3.0"}]}`)
+
+	updater, err := NewUpdater(&config.BumpFileConfig{Type: config.BumpFileTypeJSON, Path: "other"})
+	assert.NoError(t, err)
+
+	_, err = updater.Update([]byte(`{"version": "1.2.0"}`), "1.3.0")
+	assert.True(t, errors.Is(err, ErrVersionNotFound))
+}
+
+func TestXMLUpdater(t *testing.T) {
+	cfg := &config.BumpFileConfig{Type: config.BumpFileTypeXML, Path: "project/version"}
+
+	testUpdate(t, cfg,
+		"<?xml version=\"1.0\"?>\n<project>\n  <parent><version>2.0.0</version></parent>\n  <!-- version -->\n  <version>1.2.0</version>\n</project>\n",
+		"<?xml version=\"1.0\"?>\n<project>\n  <parent><version>2.0.0</version></parent>\n  <!-- version -->\n  <version>1.3.0</version>\n</project>\n")
+
+	updater, err := NewUpdater(&config.BumpFileConfig{Type: config.BumpFileTypeXML, Path: "project/version"})
+	assert.NoError(t, err)
+
+	_, err = updater.Update([]byte(`<project><version/></project>`), "1.3.0")
+	assert.Error(t, err)
+}
+
+func TestYAMLUpdater(t *testing.T) {
+	cfg := &config.BumpFileConfig{Type: config.BumpFileTypeYAML, Path: "app.version"}
+
+	testUpdate(t, cfg,
+		"# Chart\nname: test\napp:\n  version: 1.2.0 # current\n",
+		"# Chart\nname: test\napp:\n  version: 1.3.0 # current\n")
+	testUpdate(t, cfg,
+		"app:\n  version: \"1.2.0\"\n",
+		"app:\n  version: \"1.3.0\"\n")
+	testUpdate(t, cfg,
+		"app: {name: 'ä', version: '1.2.0'}\n",
+		"app: {name: 'ä', version: '1.3.0'}\n")
+
+	cfg = &config.BumpFileConfig{Type: config.BumpFileTypeYAML, Path: "images.0.tag"}
+
+	testUpdate(t, cfg,
+		"images:\n  - tag: 1.2.0\n",
+		"images:\n  - tag: 1.3.0\n")
+}
+
+func TestTOMLUpdater(t *testing.T) {
+	cfg := &config.BumpFileConfig{Type: config.BumpFileTypeTOML, Path: "package.version"}
+
+	testUpdate(t, cfg,
+		"version = \"0.0.1\"\n\n[package]\nname = \"test\"\nversion = \"1.2.0\" # current\n\n[dependencies]\nversion = \"2.0.0\"\n",
+		"version = \"0.0.1\"\n\n[package]\nname = \"test\"\nversion = \"1.3.0\" # current\n\n[dependencies]\nversion = \"2.0.0\"\n")
+	testUpdate(t, cfg,
+		"package.version = '1.2.0'\n",
+		"package.version = '1.3.0'\n")
+}
+
+func TestRegexUpdater(t *testing.T) {
+	cfg := &config.BumpFileConfig{Type: config.BumpFileTypeRegex, Pattern: `const Version = "([^"]+)"`}
+
+	testUpdate(t, cfg,
+		"package main\n\nconst Version = \"1.2.0\"\n",
+		"package main\n\nconst Version = \"1.3.0\"\n")
+
+	cfg = &config.BumpFileConfig{Type: config.BumpFileTypeRegex, Pattern: `(v|V)ersion: (?P<version>\S+)`}
+
+	testUpdate(t, cfg,
+		"Version: 1.2.0\n",
+		"Version: 1.3.0\n")
+}
+
+func TestBumper(t *testing.T) {
+	dir := t.TempDir()
+
+	err := ioutil.WriteFile(path.Join(dir, "package.json"), []byte("{\"version\": \"1.2.0\"}\n"), 0644)
+	assert.NoError(t, err)
+
+	files := []*config.BumpFileConfig{
+		{
+			File: "package.json",
+			Type: config.BumpFileTypeJSON,
+			Path: "version",
+		},
+	}
+	versionInfo := &semver.VersionInfo{Major: 1, Minor: 3, Patch: 0, ReleaseChannel: semver.ReleaseChannelFinal}
+
+	bumper := NewBumper(files, dir)
+
+	changes, err := bumper.Bump("v1.3.0", versionInfo, true)
+	assert.NoError(t, err)
+	assert.Len(t, changes, 1)
+	assert.Equal(t, "1.3.0", changes[0].Version)
+	assert.True(t, changes[0].IsChanged())
+
+	diff, err := changes[0].Diff()
+	assert.NoError(t, err)
+	assert.Contains(t, diff, "-{\"version\": \"1.2.0\"}\n+{\"version\": \"1.3.0\"}\n")
+
+	content, err := ioutil.ReadFile(path.Join(dir, "package.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"version\": \"1.2.0\"}\n", string(content))
+
+	_, err = bumper.Bump("v1.3.0", versionInfo, false)
+	assert.NoError(t, err)
+
+	content, err = ioutil.ReadFile(path.Join(dir, "package.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"version\": \"1.3.0\"}\n", string(content))
+}
+
+func TestBumperFailure(t *testing.T) {
+	dir := t.TempDir()
+
+	err := ioutil.WriteFile(path.Join(dir, "package.json"), []byte("{\"version\": \"1.2.0\"}\n"), 0644)
+	assert.NoError(t, err)
+	err = ioutil.WriteFile(path.Join(dir, "Chart.yaml"), []byte("name: chart\n"), 0644)
+	assert.NoError(t, err)
+
+	files := []*config.BumpFileConfig{
+		{
+			File: "package.json",
+			Type: config.BumpFileTypeJSON,
+			Path: "version",
+		},
+		{
+			File: "Chart.yaml",
+			Type: config.BumpFileTypeYAML,
+			Path: "version",
+		},
+	}
+	versionInfo := &semver.VersionInfo{Major: 1, Minor: 3, Patch: 0, ReleaseChannel: semver.ReleaseChannelFinal}
+
+	bumper := NewBumper(files, dir)
+
+	_, err = bumper.Bump("v1.3.0", versionInfo, false)
+	assert.ErrorIs(t, err, ErrVersionNotFound)
+
+	// The first file is not written if the second one fails
+	content, err := ioutil.ReadFile(path.Join(dir, "package.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"version\": \"1.2.0\"}\n", string(content))
+}
@@ -0,0 +1,83 @@
+package bump
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/indece-official/semantic-version/pkg/semver/config"
+)
+
+var expTOMLTable = regexp.MustCompile(`^\s*\[\s*([^\[\]]+?)\s*\]\s*(#.*)?$`)
+var expTOMLArrayTable = regexp.MustCompile(`^\s*\[\[`)
+var expTOMLKeyValue = regexp.MustCompile(`^\s*([A-Za-z0-9_\-\.\s"']+?)\s*=\s*("(?:[^"\\]|\\.)*"|'[^']*')`)
+
+// TOMLUpdater replaces a string value in a TOML file in place, keeping the
+// formatting of the file (arrays of tables and multi-line strings are not
+// supported)
+type TOMLUpdater struct {
+	path string
+}
+
+func normalizeTOMLKey(key string) string {
+	elements := strings.Split(key, ".")
+	for i, element := range elements {
+		elements[i] = strings.Trim(strings.TrimSpace(element), `"'`)
+	}
+
+	return strings.Join(elements, ".")
+}
+
+// Update implements Updater
+func (u *TOMLUpdater) Update(content []byte, version string) ([]byte, error) {
+	table := ""
+	offset := 0
+
+	for _, line := range strings.SplitAfter(string(content), "\n") {
+		lineOffset := offset
+		offset += len(line)
+
+		if expTOMLArrayTable.MatchString(line) {
+			// Arrays of tables are not supported
+			table = "[["
+			continue
+		}
+
+		matches := expTOMLTable.FindStringSubmatch(strings.TrimRight(line, "\r\n"))
+		if matches != nil {
+			table = normalizeTOMLKey(matches[1])
+			continue
+		}
+
+		loc := expTOMLKeyValue.FindStringSubmatchIndex(line)
+		if loc == nil {
+			continue
+		}
+
+		key := normalizeTOMLKey(line[loc[2]:loc[3]])
+		if table != "" {
+			key = table + "." + key
+		}
+
+		if key != u.path {
+			continue
+		}
+
+		value := fmt.Sprintf("%q", version)
+		if line[loc[4]] == '\'' {
+			value = "'" + version + "'"
+		}
+
+		return replaceRange(content, lineOffset+loc[4], lineOffset+loc[5], value), nil
+	}
+
+	return nil, fmt.Errorf("%w at %s", ErrVersionNotFound, u.path)
+}
+
+// NewTOMLUpdater creates a new updater for TOML files, the path contains the
+// table and key separated by '.' (e.g. 'package.version')
+func NewTOMLUpdater(cfg *config.BumpFileConfig) (Updater, error) {
+	return &TOMLUpdater{
+		path: cfg.Path,
+	}, nil
+}
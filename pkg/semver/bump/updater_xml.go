@@ -0,0 +1,83 @@
+package bump
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/indece-official/semantic-version/pkg/semver/config"
+)
+
+// XMLUpdater replaces the text of an element in a XML file in place, keeping
+// the formatting of the file
+type XMLUpdater struct {
+	path string
+}
+
+// Update implements Updater
+func (u *XMLUpdater) Update(content []byte, version string) ([]byte, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	stack := []string{}
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error parsing xml: %s", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			stack = append(stack, t.Name.Local)
+			if strings.Join(stack, "/") != u.path {
+				continue
+			}
+
+			start := decoder.InputOffset()
+
+			token, err = decoder.Token()
+			if err != nil {
+				return nil, fmt.Errorf("error parsing xml: %s", err)
+			}
+
+			if _, ok := token.(xml.CharData); !ok {
+				return nil, fmt.Errorf("element %s contains no text", u.path)
+			}
+
+			end := decoder.InputOffset()
+
+			token, err = decoder.Token()
+			if err != nil {
+				return nil, fmt.Errorf("error parsing xml: %s", err)
+			}
+
+			if _, ok := token.(xml.EndElement); !ok {
+				return nil, fmt.Errorf("element %s contains more than text", u.path)
+			}
+
+			value := &bytes.Buffer{}
+			err = xml.EscapeText(value, []byte(version))
+			if err != nil {
+				return nil, err
+			}
+
+			return replaceRange(content, int(start), int(end), value.String()), nil
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	return nil, fmt.Errorf("%w at %s", ErrVersionNotFound, u.path)
+}
+
+// NewXMLUpdater creates a new updater for XML files, the path contains the
+// local names of the elements separated by '/' (e.g. 'project/version')
+func NewXMLUpdater(cfg *config.BumpFileConfig) (Updater, error) {
+	return &XMLUpdater{
+		path: strings.Trim(cfg.Path, "/"),
+	}, nil
+}
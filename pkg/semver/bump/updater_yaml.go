@@ -0,0 +1,135 @@
+package bump
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/indece-official/semantic-version/pkg/semver/config"
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLUpdater replaces a scalar value in a YAML file in place, keeping the
+// formatting, comments and quoting style of the file
+type YAMLUpdater struct {
+	path []string
+}
+
+func (u *YAMLUpdater) findNode(node *yaml.Node) (*yaml.Node, error) {
+	for _, element := range u.path {
+		switch node.Kind {
+		case yaml.MappingNode:
+			var value *yaml.Node
+
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == element {
+					value = node.Content[i+1]
+					break
+				}
+			}
+
+			if value == nil {
+				return nil, fmt.Errorf("%w at %s", ErrVersionNotFound, strings.Join(u.path, "."))
+			}
+
+			node = value
+		case yaml.SequenceNode:
+			index, err := strconv.Atoi(element)
+			if err != nil || index < 0 || index >= len(node.Content) {
+				return nil, fmt.Errorf("%w at %s", ErrVersionNotFound, strings.Join(u.path, "."))
+			}
+
+			node = node.Content[index]
+		default:
+			return nil, fmt.Errorf("%w at %s", ErrVersionNotFound, strings.Join(u.path, "."))
+		}
+	}
+
+	if node.Kind != yaml.ScalarNode {
+		return nil, fmt.Errorf("value at %s is not a scalar", strings.Join(u.path, "."))
+	}
+
+	return node, nil
+}
+
+// Update implements Updater
+func (u *YAMLUpdater) Update(content []byte, version string) ([]byte, error) {
+	document := &yaml.Node{}
+
+	err := yaml.Unmarshal(content, document)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing yaml: %s", err)
+	}
+
+	if len(document.Content) == 0 {
+		return nil, fmt.Errorf("%w at %s", ErrVersionNotFound, strings.Join(u.path, "."))
+	}
+
+	node, err := u.findNode(document.Content[0])
+	if err != nil {
+		return nil, err
+	}
+
+	// Line and column are 1-based, the column counts characters
+	start := 0
+	for i := 1; i < node.Line; i++ {
+		start += bytes.IndexByte(content[start:], '\n') + 1
+	}
+	for i := 1; i < node.Column; i++ {
+		_, size := utf8.DecodeRune(content[start:])
+		start += size
+	}
+
+	var end int
+	var value string
+
+	switch node.Style {
+	case yaml.DoubleQuotedStyle:
+		end = start + 1
+		for end < len(content) && content[end] != '"' {
+			if content[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		end++
+
+		value = strconv.Quote(version)
+	case yaml.SingleQuotedStyle:
+		end = start + 1
+		for end < len(content) {
+			if content[end] == '\'' {
+				if end+1 < len(content) && content[end+1] == '\'' {
+					end += 2
+					continue
+				}
+				break
+			}
+			end++
+		}
+		end++
+
+		value = "'" + strings.ReplaceAll(version, "'", "''") + "'"
+	case 0:
+		end = start + len(node.Value)
+		value = version
+	default:
+		return nil, fmt.Errorf("unsupported style of value at %s", strings.Join(u.path, "."))
+	}
+
+	if end > len(content) {
+		return nil, fmt.Errorf("error locating value at %s", strings.Join(u.path, "."))
+	}
+
+	return replaceRange(content, start, end, value), nil
+}
+
+// NewYAMLUpdater creates a new updater for YAML files, the path contains the
+// keys (or sequence indices) separated by '.'
+func NewYAMLUpdater(cfg *config.BumpFileConfig) (Updater, error) {
+	return &YAMLUpdater{
+		path: strings.Split(cfg.Path, "."),
+	}, nil
+}
@@ -0,0 +1,246 @@
+package changelog
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ErrSectionExists is returned if the changelog file already contains a
+// section for the version
+var ErrSectionExists = errors.New("changelog already contains section")
+
+// DefaultChangelogTitle is used as header of new changelog files
+const DefaultChangelogTitle = "# Changelog\n\nAll notable changes to this project will be documented in this file.\n"
+
+// expSectionHeader matches the header of a version section:
+//
+//	## v1.2.0 - 2021-01-01
+//	## [v1.2.0](https://github.com/org/repo/compare/v1.1.0...v1.2.0) - 2021-01-01
+var expSectionHeader = regexp.MustCompile(`^##[ \t]+\[?(?P<version>[^\]\s]+)\]?`)
+
+// expSectionVersion matches the version of a section header containing a
+// dotted number (e.g. 'v1.2.0', 'api/v2024.03.1', '1.0.0rc1'), other headers
+// (e.g. '## [Unreleased]' or '## Notes') are part of the preceding content
+var expSectionVersion = regexp.MustCompile(`^\S*?\d+(\.\d+)+\S*$`)
+
+// expCodeFence matches the start or end of a fenced code block
+var expCodeFence = regexp.MustCompile("^[ \t]*(```|~~~)")
+
+// ChangelogSection is the section of a single version in a changelog file
+type ChangelogSection struct {
+	Version         string
+	PreviousVersion string
+	Date            time.Time
+	// CompareURL links the changes between PreviousVersion and Version
+	CompareURL string
+	// Body is the changelog of the version (using '###' headings)
+	Body string
+}
+
+// String formats the section as markdown
+func (s *ChangelogSection) String() string {
+	header := fmt.Sprintf("## %s", s.Version)
+	if s.CompareURL != "" {
+		header = fmt.Sprintf("## [%s](%s)", s.Version, s.CompareURL)
+	}
+
+	if !s.Date.IsZero() {
+		header += fmt.Sprintf(" - %s", s.Date.Format("2006-01-02"))
+	}
+
+	body := strings.TrimSpace(s.Body)
+	if body == "" {
+		return header + "\n"
+	}
+
+	return header + "\n\n" + body + "\n"
+}
+
+// FormatCompareURL renders a compare url template with the placeholders
+// {previous} and {version}, returns an empty string if there is no previous
+// version or no template
+func FormatCompareURL(urlTemplate string, previousVersion string, version string) string {
+	if urlTemplate == "" || previousVersion == "" {
+		return ""
+	}
+
+	url := urlTemplate
+	url = strings.ReplaceAll(url, "{previous}", previousVersion)
+	url = strings.ReplaceAll(url, "{version}", version)
+
+	return url
+}
+
+type changelogFileSection struct {
+	version string
+	content string
+}
+
+// ChangelogFile is a markdown changelog file consisting of a hand-written
+// header followed by one section per version (newest first)
+type ChangelogFile struct {
+	header   string
+	sections []*changelogFileSection
+}
+
+// HasSection checks if the file contains a section for the version
+func (f *ChangelogFile) HasSection(version string) bool {
+	return f.findSection(version) >= 0
+}
+
+func (f *ChangelogFile) findSection(version string) int {
+	for i, section := range f.sections {
+		if isSameVersion(section.version, version) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// isSameVersion compares versions of sections ignoring a leading 'v' (e.g.
+// a hand-written '## [1.2.0]' is the section of 'v1.2.0')
+func isSameVersion(a string, b string) bool {
+	trimPrefix := func(version string) string {
+		if strings.HasPrefix(version, "v") || strings.HasPrefix(version, "V") {
+			return version[1:]
+		}
+
+		return version
+	}
+
+	return trimPrefix(a) == trimPrefix(b)
+}
+
+// AddSection adds the section as newest version
+//
+// If the file already contains a section for the version, it is replaced in
+// place if replace is set, else ErrSectionExists is returned.
+func (f *ChangelogFile) AddSection(section *ChangelogSection, replace bool) error {
+	fileSection := &changelogFileSection{
+		version: section.Version,
+		content: section.String(),
+	}
+
+	i := f.findSection(section.Version)
+	if i >= 0 {
+		if !replace {
+			return fmt.Errorf("%w for %s", ErrSectionExists, section.Version)
+		}
+
+		f.sections[i] = fileSection
+
+		return nil
+	}
+
+	f.sections = append([]*changelogFileSection{fileSection}, f.sections...)
+
+	return nil
+}
+
+// ReplaceSections replaces all sections with the given sections (newest
+// first), existing sections of other versions (e.g. hand-written sections of
+// old versions) are kept after the new sections
+func (f *ChangelogFile) ReplaceSections(sections []*ChangelogSection) {
+	newSections := []*changelogFileSection{}
+
+	for _, section := range sections {
+		newSections = append(newSections, &changelogFileSection{
+			version: section.Version,
+			content: section.String(),
+		})
+	}
+
+	for _, section := range f.sections {
+		replaced := false
+		for _, newSection := range sections {
+			if isSameVersion(section.version, newSection.Version) {
+				replaced = true
+
+				break
+			}
+		}
+
+		if !replaced {
+			newSections = append(newSections, section)
+		}
+	}
+
+	f.sections = newSections
+}
+
+// String formats the changelog file as markdown
+func (f *ChangelogFile) String() string {
+	parts := []string{}
+
+	if strings.TrimSpace(f.header) != "" {
+		parts = append(parts, strings.TrimRight(f.header, "\n")+"\n")
+	}
+
+	for _, section := range f.sections {
+		parts = append(parts, strings.TrimRight(section.content, "\n")+"\n")
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+// ParseChangelogFile parses the content of a changelog file, an empty
+// content results in a new file with the default title
+//
+// Only '##' headers with a version start a section, so a '## [Unreleased]'
+// section before the first version stays at the top as part of the header.
+// Headers in fenced code blocks are ignored.
+func ParseChangelogFile(content string) *ChangelogFile {
+	file := &ChangelogFile{
+		sections: []*changelogFileSection{},
+	}
+
+	if strings.TrimSpace(content) == "" {
+		file.header = DefaultChangelogTitle
+
+		return file
+	}
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	headerLines := []string{}
+	var section *changelogFileSection
+	codeFence := ""
+
+	for _, line := range lines {
+		fence := expCodeFence.FindStringSubmatch(line)
+		if fence != nil && (codeFence == "" || codeFence == fence[1]) {
+			if codeFence == "" {
+				codeFence = fence[1]
+			} else {
+				codeFence = ""
+			}
+		}
+
+		match := expSectionHeader.FindStringSubmatch(line)
+		if codeFence == "" && match != nil {
+			version := match[expSectionHeader.SubexpIndex("version")]
+
+			if expSectionVersion.MatchString(version) {
+				section = &changelogFileSection{
+					version: version,
+				}
+				file.sections = append(file.sections, section)
+			}
+		}
+
+		if section == nil {
+			headerLines = append(headerLines, line)
+
+			continue
+		}
+
+		section.content += line + "\n"
+	}
+
+	file.header = strings.Join(headerLines, "\n")
+
+	return file
+}
@@ -0,0 +1,153 @@
+package changelog
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testChangelogFile = `# My Changelog
+
+Hand-written intro.
+
+## [v1.1.0](https://example.com/compare/v1.0.0...v1.1.0) - 2021-02-01
+
+### Features
+* Some feature (abc)
+
+## v1.0.0 - 2021-01-01
+
+Hand-written notes.
+`
+
+func TestChangelogFileAddSection(t *testing.T) {
+	file := ParseChangelogFile(testChangelogFile)
+	assert.True(t, file.HasSection("v1.1.0"))
+	assert.True(t, file.HasSection("v1.0.0"))
+	assert.False(t, file.HasSection("v1.2.0"))
+
+	err := file.AddSection(&ChangelogSection{
+		Version:    "v1.2.0",
+		Date:       time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+		CompareURL: FormatCompareURL("https://example.com/compare/{previous}...{version}", "v1.1.0", "v1.2.0"),
+		Body:       "### Fixes\n* Some fix (def)\n\n",
+	}, false)
+	assert.NoError(t, err)
+
+	assert.Equal(t, `# My Changelog
+
+Hand-written intro.
+
+## [v1.2.0](https://example.com/compare/v1.1.0...v1.2.0) - 2021-03-01
+
+### Fixes
+* Some fix (def)
+
+## [v1.1.0](https://example.com/compare/v1.0.0...v1.1.0) - 2021-02-01
+
+### Features
+* Some feature (abc)
+
+## v1.0.0 - 2021-01-01
+
+Hand-written notes.
+`, file.String())
+}
+
+func TestChangelogFileAddSectionExists(t *testing.T) {
+	file := ParseChangelogFile(testChangelogFile)
+
+	section := &ChangelogSection{
+		Version: "v1.1.0",
+		Body:    "### Fixes\n* Some fix (def)\n",
+	}
+
+	err := file.AddSection(section, false)
+	assert.ErrorIs(t, err, ErrSectionExists)
+
+	err = file.AddSection(section, true)
+	assert.NoError(t, err)
+	assert.Contains(t, file.String(), "## v1.1.0\n\n### Fixes\n* Some fix (def)\n\n## v1.0.0 - 2021-01-01\n")
+}
+
+func TestChangelogFileReplaceSections(t *testing.T) {
+	file := ParseChangelogFile(testChangelogFile)
+
+	file.ReplaceSections([]*ChangelogSection{
+		{Version: "v1.2.0"},
+		{Version: "v1.1.0"},
+	})
+
+	assert.Equal(t, "# My Changelog\n\nHand-written intro.\n\n## v1.2.0\n\n## v1.1.0\n\n## v1.0.0 - 2021-01-01\n\nHand-written notes.\n", file.String())
+}
+
+func TestChangelogFileNew(t *testing.T) {
+	file := ParseChangelogFile("")
+
+	err := file.AddSection(&ChangelogSection{
+		Version: "v1.0.0",
+	}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultChangelogTitle+"\n## v1.0.0\n", file.String())
+}
+
+func TestChangelogFileVersionHeaders(t *testing.T) {
+	file := ParseChangelogFile("# Changelog\n\n## v1.1.0\n\n## Upgrade notes\n\nSee below.\n\n## 1.0.0rc1 - 2021-01-01\n")
+	assert.True(t, file.HasSection("v1.1.0"))
+	assert.True(t, file.HasSection("1.0.0rc1"))
+	assert.False(t, file.HasSection("Upgrade"))
+
+	file.ReplaceSections([]*ChangelogSection{
+		{Version: "v1.1.0"},
+	})
+
+	// Non-version headers are kept as part of the preceding section
+	assert.Equal(t, "# Changelog\n\n## v1.1.0\n\n## 1.0.0rc1 - 2021-01-01\n", file.String())
+}
+
+func TestChangelogFileUnreleased(t *testing.T) {
+	file := ParseChangelogFile("# Changelog\n\n## [Unreleased]\n\n### Added\n- Upcoming feature\n\n## [v1.0.0] - 2021-01-01\n")
+	assert.False(t, file.HasSection("Unreleased"))
+
+	err := file.AddSection(&ChangelogSection{
+		Version: "v1.1.0",
+	}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "# Changelog\n\n## [Unreleased]\n\n### Added\n- Upcoming feature\n\n## v1.1.0\n\n## [v1.0.0] - 2021-01-01\n", file.String())
+
+	file.ReplaceSections([]*ChangelogSection{
+		{Version: "v1.2.0"},
+	})
+	assert.True(t, strings.HasPrefix(file.String(), "# Changelog\n\n## [Unreleased]\n\n### Added\n- Upcoming feature\n\n## v1.2.0\n"))
+}
+
+func TestChangelogFileCodeBlocks(t *testing.T) {
+	file := ParseChangelogFile("# Changelog\n\n## v1.1.0\n\nMigration:\n\n```markdown\n## v1.0.0\n~~~\n```\n\n~~~\n## 0.9.0\n~~~\n")
+	assert.True(t, file.HasSection("v1.1.0"))
+	assert.False(t, file.HasSection("v1.0.0"))
+	assert.False(t, file.HasSection("0.9.0"))
+
+	err := file.AddSection(&ChangelogSection{
+		Version: "v1.0.0",
+	}, false)
+	assert.NoError(t, err)
+	assert.True(t, file.HasSection("v1.0.0"))
+}
+
+func TestChangelogFileVersionPrefix(t *testing.T) {
+	file := ParseChangelogFile("# Changelog\n\n## [1.2.0] - 2021-01-01\n\nHand-written notes.\n")
+	assert.True(t, file.HasSection("v1.2.0"))
+
+	err := file.AddSection(&ChangelogSection{
+		Version: "v1.2.0",
+	}, false)
+	assert.ErrorIs(t, err, ErrSectionExists)
+
+	file.ReplaceSections([]*ChangelogSection{
+		{Version: "v1.3.0"},
+		{Version: "v1.2.0"},
+	})
+	assert.Equal(t, "# Changelog\n\n## v1.3.0\n\n## v1.2.0\n", file.String())
+}
@@ -0,0 +1,242 @@
+package changelog
+
+import (
+	"embed"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/indece-official/semantic-version/pkg/semver"
+)
+
+// Names of the built-in changelog templates
+const (
+	TemplateMarkdown       = "markdown"
+	TemplateKeepAChangelog = "keep-a-changelog"
+	TemplateText           = "text"
+	TemplateHTML           = "html"
+)
+
+// BuiltinTemplates contains the names of all built-in changelog templates
+var BuiltinTemplates = []string{
+	TemplateMarkdown,
+	TemplateKeepAChangelog,
+	TemplateText,
+	TemplateHTML,
+}
+
+//go:embed templates/*.tmpl
+var builtinTemplateFiles embed.FS
+
+// ChangelogData is passed to changelog templates
+type ChangelogData struct {
+	Version         string
+	PreviousVersion string
+	// VersionInfo contains the components of Version, nil if unknown
+	VersionInfo *semver.VersionInfo
+	Date        time.Time
+	CompareURL  string
+	// HeadingLevel is the level of the top-level headings (e.g. 3 for '###')
+	HeadingLevel int
+	Breaking     []*ParsedCommit
+	// Groups contains all groups with a changelog heading and at least one
+	// commit, commits of hidden scopes are omitted
+	Groups  []*CommitGroup
+	Unknown []*ParsedCommit
+	// Scopes contains all scopes of the listed commits (sorted)
+	Scopes []string
+	// Authors contains the names of all authors of the listed commits (sorted)
+	Authors []string
+	// Contributors contains all authors and co-authors of the parsed commits,
+	// empty if the contributors section is disabled
+	Contributors []*Contributor
+	// GroupByScope is true if the commits of each section are grouped by scope
+	GroupByScope bool
+
+	commitParser *CommitParser
+}
+
+// ScopeGroups groups commits by scope if GroupByScope is enabled (commits
+// without scope first, then the configured scopes), otherwise all commits are
+// returned in a single group without scope
+func (d *ChangelogData) ScopeGroups(commits []*ParsedCommit) []*ScopeGroup {
+	return d.commitParser.getScopeGroups(commits)
+}
+
+// Commits returns all listed commits (breaking changes and groups)
+func (d *ChangelogData) Commits() []*ParsedCommit {
+	commits := []*ParsedCommit{}
+	commits = append(commits, d.Breaking...)
+
+	for _, group := range d.Groups {
+		commits = append(commits, group.Commits...)
+	}
+
+	return commits
+}
+
+// CommitsOfType returns all non-breaking commits of the groups of the commit types
+func (d *ChangelogData) CommitsOfType(types ...string) []*ParsedCommit {
+	commits := []*ParsedCommit{}
+
+	for _, group := range d.Groups {
+		if containsString(types, group.CommitType.Type) {
+			commits = append(commits, group.Commits...)
+		}
+	}
+
+	return commits
+}
+
+// CommitsNotOfType returns all non-breaking commits of the groups of all other commit types
+func (d *ChangelogData) CommitsNotOfType(types ...string) []*ParsedCommit {
+	commits := []*ParsedCommit{}
+
+	for _, group := range d.Groups {
+		if !containsString(types, group.CommitType.Type) {
+			commits = append(commits, group.Commits...)
+		}
+	}
+
+	return commits
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sortedKeys(values map[string]bool) []string {
+	keys := []string{}
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// GetChangelogData returns the template data of all parsed commits, the
+// version fields have to be set by the caller
+func (c *CommitParser) GetChangelogData(headingLevel int) *ChangelogData {
+	data := &ChangelogData{
+		HeadingLevel: headingLevel,
+		Breaking:     c.Breaking,
+		Groups:       c.getChangelogGroups(),
+		Unknown:      c.Unknown,
+		GroupByScope: c.groupByScope,
+		commitParser: c,
+	}
+
+	if data.Breaking == nil {
+		data.Breaking = []*ParsedCommit{}
+	}
+
+	if data.Unknown == nil {
+		data.Unknown = []*ParsedCommit{}
+	}
+
+	scopes := map[string]bool{}
+	authors := map[string]bool{}
+
+	for _, parsedCommit := range data.Commits() {
+		if parsedCommit.Scope != "" {
+			scopes[parsedCommit.Scope] = true
+		}
+
+		if parsedCommit.Author != "" {
+			authors[parsedCommit.Author] = true
+		}
+	}
+
+	data.Scopes = sortedKeys(scopes)
+	data.Authors = sortedKeys(authors)
+
+	data.Contributors = []*Contributor{}
+	if c.showContributors {
+		data.Contributors = c.GetContributors()
+	}
+
+	return data
+}
+
+var templateFuncs = template.FuncMap{
+	"heading": func(level int) string {
+		return strings.Repeat("#", level)
+	},
+	"indent": func(spaces int, text string) string {
+		return strings.ReplaceAll(text, "\n", "\n"+strings.Repeat(" ", spaces))
+	},
+	"join": func(values []string, sep string) string {
+		return strings.Join(values, sep)
+	},
+	"date": func(layout string, t time.Time) string {
+		return t.Format(layout)
+	},
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"trim":  strings.TrimSpace,
+}
+
+// ChangelogTemplate renders changelogs using a go text/template
+// (see https://pkg.go.dev/text/template)
+type ChangelogTemplate struct {
+	tpl *template.Template
+}
+
+// Render renders the changelog
+func (t *ChangelogTemplate) Render(data *ChangelogData) (string, error) {
+	var buf strings.Builder
+
+	err := t.tpl.Execute(&buf, data)
+	if err != nil {
+		return "", fmt.Errorf("can't render changelog template: %s", err)
+	}
+
+	return buf.String(), nil
+}
+
+// NewChangelogTemplate parses a changelog template
+func NewChangelogTemplate(name string, text string) (*ChangelogTemplate, error) {
+	tpl, err := template.New(name).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse changelog template %s: %s", name, err)
+	}
+
+	return &ChangelogTemplate{
+		tpl: tpl,
+	}, nil
+}
+
+// LoadChangelogTemplate loads a built-in template (see BuiltinTemplates),
+// an inline template (containing '{{') or a template file
+func LoadChangelogTemplate(value string) (*ChangelogTemplate, error) {
+	if containsString(BuiltinTemplates, value) {
+		text, err := builtinTemplateFiles.ReadFile(fmt.Sprintf("templates/%s.tmpl", value))
+		if err != nil {
+			return nil, fmt.Errorf("can't load built-in changelog template %s: %s", value, err)
+		}
+
+		return NewChangelogTemplate(value, string(text))
+	}
+
+	if strings.Contains(value, "{{") {
+		return NewChangelogTemplate("inline", value)
+	}
+
+	text, err := ioutil.ReadFile(value)
+	if err != nil {
+		return nil, fmt.Errorf("can't read changelog template %s: %s", value, err)
+	}
+
+	return NewChangelogTemplate(value, string(text))
+}
@@ -0,0 +1,166 @@
+package changelog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestChangelogData(t *testing.T, headingLevel int) *ChangelogData {
+	author := object.Signature{
+		Name:  "Jane Doe",
+		Email: "jane@example.com",
+		When:  time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	commits := []*object.Commit{
+		{Hash: plumbing.NewHash("01"), Author: author, Message: "feat(api)!: Changed API model to v2\n\nBREAKING CHANGE: Clients have to be updated"},
+		{Hash: plumbing.NewHash("02"), Author: author, Message: "feat: Added <b>bold</b> text (#12)"},
+		{Hash: plumbing.NewHash("03"), Author: author, Message: "fix(db): Fixed index\n\nCloses #7"},
+		{Hash: plumbing.NewHash("04"), Author: author, Message: "perf: Faster startup"},
+		{Hash: plumbing.NewHash("05"), Author: author, Message: "docs: Updated README.md"},
+	}
+
+	commitParser := NewCommitParser(newTestCommitTypes(t))
+	commitParser.Parse(commits)
+
+	data := commitParser.GetChangelogData(headingLevel)
+	data.Version = "v2.0.0"
+	data.PreviousVersion = "v1.4.0"
+	data.Date = author.When
+
+	return data
+}
+
+func renderTestTemplate(t *testing.T, name string, headingLevel int) string {
+	tpl, err := LoadChangelogTemplate(name)
+	assert.NoError(t, err)
+
+	output, err := tpl.Render(newTestChangelogData(t, headingLevel))
+	assert.NoError(t, err)
+
+	return output
+}
+
+func TestCommitParserGetChangelogData(t *testing.T) {
+	data := newTestChangelogData(t, 1)
+
+	assert.Len(t, data.Breaking, 1)
+	assert.Len(t, data.Groups, 3)
+	assert.Equal(t, []string{"api", "db"}, data.Scopes)
+	assert.Equal(t, []string{"Jane Doe"}, data.Authors)
+	assert.Equal(t, []string{"#12"}, data.Groups[0].Commits[0].Issues)
+	assert.Equal(t, []string{"#7"}, data.Groups[1].Commits[0].Issues)
+	assert.Equal(t, "0200000", data.Groups[0].Commits[0].ShortHash())
+	assert.Len(t, data.CommitsNotOfType("feat", "fix"), 1)
+}
+
+func TestChangelogTemplateMarkdown(t *testing.T) {
+	assert.Equal(t, "### BREAKING CHANGES\n"+
+		"* **api:** Changed API model to v2 (0100000)\n"+
+		"  Clients have to be updated\n"+
+		"\n"+
+		"### Features\n"+
+		"* Added <b>bold</b> text (#12) (0200000)\n"+
+		"\n"+
+		"### Fixes\n"+
+		"* **db:** Fixed index (0300000)\n"+
+		"\n"+
+		"### Performance Improvements\n"+
+		"* Faster startup (0400000)\n"+
+		"\n", renderTestTemplate(t, TemplateMarkdown, 3))
+}
+
+func TestChangelogTemplateKeepAChangelog(t *testing.T) {
+	assert.Equal(t, "### Added\n"+
+		"- Added <b>bold</b> text (#12) (0200000)\n"+
+		"\n"+
+		"### Changed\n"+
+		"- **BREAKING:** **api:** Changed API model to v2 (0100000)\n"+
+		"  Clients have to be updated\n"+
+		"- Faster startup (0400000)\n"+
+		"\n"+
+		"### Fixed\n"+
+		"- **db:** Fixed index (0300000)\n"+
+		"\n", renderTestTemplate(t, TemplateKeepAChangelog, 3))
+}
+
+func TestChangelogTemplateText(t *testing.T) {
+	assert.Equal(t, "BREAKING CHANGES\n"+
+		"  - api: Changed API model to v2 (0100000)\n"+
+		"    Clients have to be updated\n"+
+		"\n"+
+		"Features\n"+
+		"  - Added <b>bold</b> text (#12) (0200000)\n"+
+		"\n"+
+		"Fixes\n"+
+		"  - db: Fixed index (0300000)\n"+
+		"\n"+
+		"Performance Improvements\n"+
+		"  - Faster startup (0400000)\n"+
+		"\n", renderTestTemplate(t, TemplateText, 1))
+}
+
+func TestChangelogTemplateHTML(t *testing.T) {
+	assert.Equal(t, "<h2>BREAKING CHANGES</h2>\n"+
+		"<ul>\n"+
+		"  <li><strong>api:</strong> Changed API model to v2 (<code>0100000</code>)<br>Clients have to be updated</li>\n"+
+		"</ul>\n"+
+		"<h2>Features</h2>\n"+
+		"<ul>\n"+
+		"  <li>Added &lt;b&gt;bold&lt;/b&gt; text (#12) (<code>0200000</code>)</li>\n"+
+		"</ul>\n"+
+		"<h2>Fixes</h2>\n"+
+		"<ul>\n"+
+		"  <li><strong>db:</strong> Fixed index (<code>0300000</code>)</li>\n"+
+		"</ul>\n"+
+		"<h2>Performance Improvements</h2>\n"+
+		"<ul>\n"+
+		"  <li>Faster startup (<code>0400000</code>)</li>\n"+
+		"</ul>\n", renderTestTemplate(t, TemplateHTML, 2))
+}
+
+func TestChangelogTemplateInline(t *testing.T) {
+	output := renderTestTemplate(t, "{{ .Version }} ({{ date \"2006-01-02\" .Date }}) by {{ join .Authors \", \" }}{{ range .Commits }}\n{{ .Message }} {{ join .Issues \",\" }}{{ end }}", 1)
+
+	assert.Equal(t, "v2.0.0 (2021-03-01) by Jane Doe\n"+
+		"Changed API model to v2 \n"+
+		"Added <b>bold</b> text (#12) #12\n"+
+		"Fixed index #7\n"+
+		"Faster startup ", output)
+}
+
+func TestLoadChangelogTemplateInvalid(t *testing.T) {
+	_, err := LoadChangelogTemplate("{{ .Version ")
+	assert.Error(t, err)
+
+	_, err = LoadChangelogTemplate("/does/not/exist.tmpl")
+	assert.Error(t, err)
+}
+
+func TestChangelogTemplateGroupByScope(t *testing.T) {
+	commits := []*object.Commit{
+		{Hash: plumbing.NewHash("01"), Message: "feat(api): Added endpoint"},
+		{Hash: plumbing.NewHash("02"), Message: "feat: Added export"},
+		{Hash: plumbing.NewHash("03"), Message: "fix(ci): Fixed pipeline"},
+	}
+
+	commitParser := NewCommitParser(newTestCommitTypes(t))
+	commitParser.SetScopes([]*Scope{}, []string{"ci"})
+	commitParser.SetGroupByScope(true)
+	commitParser.Parse(commits)
+
+	tpl, err := LoadChangelogTemplate(TemplateKeepAChangelog)
+	assert.NoError(t, err)
+
+	output, err := tpl.Render(commitParser.GetChangelogData(3))
+	assert.NoError(t, err)
+	assert.Equal(t, "### Added\n"+
+		"- Added export (0200000)\n"+
+		"- **api**\n"+
+		"  - Added endpoint (0100000)\n"+
+		"\n", output)
+}
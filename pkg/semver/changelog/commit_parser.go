@@ -0,0 +1,633 @@
+// Package changelog parses commit messages and generates changelogs.
+package changelog
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/indece-official/semantic-version/pkg/semver"
+)
+
+// expCommitHeader matches a conventional commit header:
+//
+//	<type>[(<scope>)][!]: <description>
+var expCommitHeader = regexp.MustCompile(`^(?P<type>[a-zA-Z][a-zA-Z0-9\-]*)(?:\((?P<scope>[^()\r\n]*)\))?(?P<breaking>!)?:[ \t]*(?P<description>.*)$`)
+
+// expCommitFooter matches a conventional commit footer (git trailer):
+//
+//	<token>: <value>
+//	<token> #<value>
+var expCommitFooter = regexp.MustCompile(`^(?P<token>BREAKING CHANGE|BREAKING-CHANGE|[a-zA-Z0-9\-]+)(?P<separator>:[ \t]|[ \t]#)(?P<value>.*)$`)
+
+// CommitFooter is a footer (git trailer) of a commit message
+type CommitFooter struct {
+	Token string
+	Value string
+}
+
+// IsBreakingChange returns true if the footer is a 'BREAKING CHANGE' footer
+func (f *CommitFooter) IsBreakingChange() bool {
+	return f.Token == "BREAKING CHANGE" || f.Token == "BREAKING-CHANGE"
+}
+
+// ParsedCommit is a single change parsed from a commit message
+type ParsedCommit struct {
+	// Type is the lower-cased commit type (e.g. 'feat'), empty if the
+	// commit message doesn't follow the conventional commits format
+	Type string
+	// Scope is the scope from the commit header, aliases are replaced by the
+	// name of the configured scope
+	Scope string
+	// Component is the label of the scope in changelogs
+	Component string
+	Breaking  bool
+	// Message is the description from the commit header
+	Message string
+	Body    string
+	Footers []*CommitFooter
+	Hash    string
+	// Issues contains all issue references (e.g. '#123') of the commit message
+	Issues []string
+	// IssueReferences contains the details (tracker, id, link) of Issues
+	IssueReferences []*IssueReference
+	// Author and AuthorEmail are normalized by the mailmap
+	Author      string
+	AuthorEmail string
+	Date        time.Time
+	// Committer and CommitterEmail are normalized by the mailmap
+	Committer      string
+	CommitterEmail string
+	// CoAuthors contains the identities of the 'Co-authored-by' footers
+	CoAuthors []*Identity
+	// CommitType is the configured commit type matching Type, nil if unknown
+	CommitType *CommitType
+}
+
+// ShortHash returns the abbreviated commit hash
+func (p *ParsedCommit) ShortHash() string {
+	if len(p.Hash) > 7 {
+		return p.Hash[:7]
+	}
+
+	return p.Hash
+}
+
+// IsBreaking returns true if the commit is marked as breaking change or
+// its commit type causes a major version increment
+func (p *ParsedCommit) IsBreaking() bool {
+	return p.Breaking ||
+		(p.CommitType != nil && p.CommitType.GetIncrementLevel() == semver.VersionIncrementLevelMajor)
+}
+
+// GetIncrementLevel returns the version increment caused by the commit
+func (p *ParsedCommit) GetIncrementLevel() semver.VersionIncrementLevel {
+	switch {
+	case p.IsBreaking():
+		return semver.VersionIncrementLevelMajor
+	case p.CommitType != nil:
+		return p.CommitType.GetIncrementLevel()
+	default:
+		return semver.VersionIncrementLevelBuild
+	}
+}
+
+// GetFooters returns all footers with the given token (case-insensitive)
+func (p *ParsedCommit) GetFooters(token string) []*CommitFooter {
+	footers := []*CommitFooter{}
+
+	for _, footer := range p.Footers {
+		if strings.EqualFold(footer.Token, token) {
+			footers = append(footers, footer)
+		}
+	}
+
+	return footers
+}
+
+// GetBreakingChangeNotes returns the descriptions of all 'BREAKING CHANGE' footers
+func (p *ParsedCommit) GetBreakingChangeNotes() []string {
+	notes := []string{}
+
+	for _, footer := range p.Footers {
+		if footer.IsBreakingChange() {
+			notes = append(notes, footer.Value)
+		}
+	}
+
+	return notes
+}
+
+// CommitGroup contains all parsed commits of a commit type
+type CommitGroup struct {
+	CommitType *CommitType
+	Commits    []*ParsedCommit
+}
+
+// CommitParser classifies commits by their commit type
+type CommitParser struct {
+	commitTypes   []*CommitType
+	issuePatterns []*IssuePattern
+	mailmap       *Mailmap
+	scopes        []*Scope
+	hiddenScopes  []string
+	groupByScope  bool
+	// identity key => hash of the first contribution
+	firstContributions map[string]string
+	showContributors   bool
+	logger             semver.Logger
+	Breaking           []*ParsedCommit
+	// Groups contains one group per configured commit type (in order of config)
+	Groups  []*CommitGroup
+	Unknown []*ParsedCommit
+}
+
+// splitHeader splits the header into multiple changes separated by ';'
+//
+// A new change is only started if the part following the ';' is a valid
+// commit header itself, so descriptions may still contain ';'.
+func splitHeader(header string) []string {
+	parts := []string{}
+
+	for _, part := range strings.Split(header, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		if len(parts) > 0 && !expCommitHeader.MatchString(part) {
+			parts[len(parts)-1] += "; " + part
+
+			continue
+		}
+
+		parts = append(parts, part)
+	}
+
+	return parts
+}
+
+// splitBodyAndFooters separates the footers (last paragraph consisting of
+// git trailers) from the body of a commit message
+func splitBodyAndFooters(lines []string) (string, []*CommitFooter) {
+	paragraphs := [][]string{}
+	paragraph := []string{}
+
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" {
+			if len(paragraph) > 0 {
+				paragraphs = append(paragraphs, paragraph)
+				paragraph = []string{}
+			}
+
+			continue
+		}
+
+		paragraph = append(paragraph, line)
+	}
+
+	if len(paragraph) > 0 {
+		paragraphs = append(paragraphs, paragraph)
+	}
+
+	footers := []*CommitFooter{}
+
+	if len(paragraphs) > 0 {
+		lastParagraph := paragraphs[len(paragraphs)-1]
+
+		if expCommitFooter.MatchString(lastParagraph[0]) {
+			paragraphs = paragraphs[:len(paragraphs)-1]
+
+			for _, line := range lastParagraph {
+				match := expCommitFooter.FindStringSubmatch(line)
+				if match == nil {
+					// Continuation of the previous footer's value
+					footer := footers[len(footers)-1]
+					footer.Value += "\n" + strings.TrimSpace(line)
+
+					continue
+				}
+
+				footer := &CommitFooter{
+					Token: match[expCommitFooter.SubexpIndex("token")],
+					Value: strings.TrimSpace(match[expCommitFooter.SubexpIndex("value")]),
+				}
+
+				if strings.TrimSpace(match[expCommitFooter.SubexpIndex("separator")]) == "#" {
+					footer.Value = "#" + footer.Value
+				}
+
+				footers = append(footers, footer)
+			}
+		}
+	}
+
+	bodyParagraphs := []string{}
+	for _, paragraph := range paragraphs {
+		bodyParagraphs = append(bodyParagraphs, strings.Join(paragraph, "\n"))
+	}
+
+	return strings.Join(bodyParagraphs, "\n\n"), footers
+}
+
+// ParseCommitMessage parses a commit message following the conventional
+// commits specification (https://www.conventionalcommits.org/en/v1.0.0/)
+// using the default issue patterns
+//
+// Multiple changes can be listed in the header separated by ';', body and
+// footers are assigned to the first change.
+func ParseCommitMessage(message string, hash string) []*ParsedCommit {
+	return ParseCommitMessageWithIssuePatterns(message, hash, DefaultIssuePatterns)
+}
+
+// ParseCommitMessageWithIssuePatterns parses a commit message like
+// ParseCommitMessage detecting issue references with the (parsed) issue
+// patterns
+func ParseCommitMessageWithIssuePatterns(message string, hash string, issuePatterns []*IssuePattern) []*ParsedCommit {
+	if len(issuePatterns) == 0 {
+		issuePatterns = DefaultIssuePatterns
+	}
+
+	parsedCommits := []*ParsedCommit{}
+
+	lines := strings.Split(strings.TrimSpace(message), "\n")
+	header := strings.TrimSpace(lines[0])
+	body, footers := splitBodyAndFooters(lines[1:])
+
+	for i, part := range splitHeader(header) {
+		parsedCommit := &ParsedCommit{
+			Message: part,
+			Hash:    hash,
+			Footers: []*CommitFooter{},
+		}
+
+		match := expCommitHeader.FindStringSubmatch(part)
+		if match != nil {
+			parsedCommit.Type = strings.ToLower(match[expCommitHeader.SubexpIndex("type")])
+			parsedCommit.Scope = strings.TrimSpace(match[expCommitHeader.SubexpIndex("scope")])
+			parsedCommit.Breaking = match[expCommitHeader.SubexpIndex("breaking")] != ""
+			parsedCommit.Message = strings.TrimSpace(match[expCommitHeader.SubexpIndex("description")])
+		}
+
+		texts := []string{parsedCommit.Message}
+
+		if i == 0 {
+			parsedCommit.Body = body
+			parsedCommit.Footers = footers
+
+			if parsedCommit.Type != "" && len(parsedCommit.GetBreakingChangeNotes()) > 0 {
+				parsedCommit.Breaking = true
+			}
+
+			texts = append(texts, body)
+			for _, footer := range footers {
+				texts = append(texts, footer.Value)
+			}
+		}
+
+		parsedCommit.Issues = []string{}
+		parsedCommit.IssueReferences = FindIssueReferences(issuePatterns, texts...)
+		for _, reference := range parsedCommit.IssueReferences {
+			parsedCommit.Issues = append(parsedCommit.Issues, reference.Issue)
+		}
+
+		parsedCommits = append(parsedCommits, parsedCommit)
+	}
+
+	return parsedCommits
+}
+
+// GetGroup returns the group of a commit type
+func (c *CommitParser) GetGroup(commitType *CommitType) *CommitGroup {
+	for _, group := range c.Groups {
+		if group.CommitType == commitType {
+			return group
+		}
+	}
+
+	return nil
+}
+
+// Parse parses and classifies the commits
+func (c *CommitParser) Parse(commits []*object.Commit) {
+	for _, commit := range commits {
+		parsedCommits := ParseCommitMessageWithIssuePatterns(commit.Message, commit.Hash.String(), c.issuePatterns)
+		contributors := GetCommitContributors(commit, c.mailmap)
+		committer, committerEmail := c.mailmap.Resolve(commit.Committer.Name, commit.Committer.Email)
+
+		for _, parsedCommit := range parsedCommits {
+			parsedCommit.CommitType = FindCommitType(c.commitTypes, parsedCommit.Type)
+			parsedCommit.Component = parsedCommit.Scope
+
+			scope := FindScope(c.scopes, parsedCommit.Scope)
+			if scope != nil {
+				parsedCommit.Scope = scope.Name
+				parsedCommit.Component = scope.Component
+			}
+			parsedCommit.Author = contributors[0].Name
+			parsedCommit.AuthorEmail = contributors[0].Email
+			parsedCommit.Date = commit.Author.When
+			parsedCommit.Committer = committer
+			parsedCommit.CommitterEmail = committerEmail
+			parsedCommit.CoAuthors = contributors[1:]
+
+			if parsedCommit.Type == "" {
+				c.logger.Debugf("Commit %s doesn't follow the conventional commits format", parsedCommit.ShortHash())
+			} else if parsedCommit.CommitType == nil {
+				c.logger.Debugf("Commit %s has unknown commit type %s", parsedCommit.ShortHash(), parsedCommit.Type)
+			}
+
+			c.logger.Debugf("Commit %s causes %s increment", parsedCommit.ShortHash(), parsedCommit.GetIncrementLevel())
+
+			switch {
+			case parsedCommit.IsBreaking():
+				c.Breaking = append(c.Breaking, parsedCommit)
+			case parsedCommit.CommitType != nil:
+				group := c.GetGroup(parsedCommit.CommitType)
+				group.Commits = append(group.Commits, parsedCommit)
+			default:
+				c.Unknown = append(c.Unknown, parsedCommit)
+			}
+		}
+	}
+}
+
+// formatIssueLinks returns the markdown links of all issue references with
+// an url (e.g. ', [#12](https://github.com/org/repo/issues/12)')
+func formatIssueLinks(parsedCommit *ParsedCommit) string {
+	str := ""
+
+	for _, reference := range parsedCommit.IssueReferences {
+		if reference.URL != "" {
+			str += ", " + reference.FormatMarkdown()
+		}
+	}
+
+	return str
+}
+
+func formatContributor(contributor *Contributor) string {
+	if contributor.FirstTime {
+		return fmt.Sprintf("* %s (first contribution)\n", contributor.Name)
+	}
+
+	return fmt.Sprintf("* %s\n", contributor.Name)
+}
+
+func formatChangelogEntry(parsedCommit *ParsedCommit) string {
+	if parsedCommit.Component != "" {
+		return fmt.Sprintf("* **%s:** %s (%s)%s\n", parsedCommit.Component, parsedCommit.Message, parsedCommit.Hash, formatIssueLinks(parsedCommit))
+	}
+
+	return fmt.Sprintf("* %s (%s)%s\n", parsedCommit.Message, parsedCommit.Hash, formatIssueLinks(parsedCommit))
+}
+
+// GenerateChangelog generates a markdown changelog of all parsed commits
+func (c *CommitParser) GenerateChangelog() string {
+	return c.GenerateChangelogWithHeadingLevel(1)
+}
+
+// GenerateChangelogWithHeadingLevel generates a markdown changelog of all
+// parsed commits using headings of the given level (e.g. 3 for '###')
+func (c *CommitParser) GenerateChangelogWithHeadingLevel(headingLevel int) string {
+	heading := strings.Repeat("#", headingLevel)
+	msg := ""
+
+	if len(c.Breaking) > 0 {
+		msg += fmt.Sprintf("%s BREAKING CHANGES\n", heading)
+		for _, parseCommit := range c.Breaking {
+			msg += formatChangelogEntry(parseCommit)
+			for _, note := range parseCommit.GetBreakingChangeNotes() {
+				msg += fmt.Sprintf("  %s\n", strings.ReplaceAll(note, "\n", "\n  "))
+			}
+		}
+		msg += "\n"
+	}
+
+	for _, group := range c.getChangelogGroups() {
+		msg += fmt.Sprintf("%s %s\n", heading, group.CommitType.ChangelogHeading)
+		for _, scopeGroup := range c.getScopeGroups(group.Commits) {
+			if scopeGroup.Scope == "" {
+				for _, parseCommit := range scopeGroup.Commits {
+					msg += formatChangelogEntry(parseCommit)
+				}
+
+				continue
+			}
+
+			msg += fmt.Sprintf("* **%s**\n", scopeGroup.Component)
+			for _, parseCommit := range scopeGroup.Commits {
+				msg += fmt.Sprintf("  * %s (%s)%s\n", parseCommit.Message, parseCommit.Hash, formatIssueLinks(parseCommit))
+			}
+		}
+		msg += "\n"
+	}
+
+	contributors := []*Contributor{}
+	if c.showContributors {
+		contributors = c.GetContributors()
+	}
+
+	if len(contributors) > 0 {
+		msg += fmt.Sprintf("%s Contributors\n", heading)
+		for _, contributor := range contributors {
+			msg += formatContributor(contributor)
+		}
+		msg += "\n"
+	}
+
+	return msg
+}
+
+// getChangelogGroups returns all groups with a changelog heading containing
+// the commits listed in changelogs (without hidden scopes), groups without
+// listed commits are omitted
+func (c *CommitParser) getChangelogGroups() []*CommitGroup {
+	groups := []*CommitGroup{}
+
+	for _, group := range c.Groups {
+		if group.CommitType.ChangelogHeading == "" {
+			continue
+		}
+
+		commits := []*ParsedCommit{}
+		for _, parsedCommit := range group.Commits {
+			if !isHiddenScope(c.hiddenScopes, parsedCommit.Scope) {
+				commits = append(commits, parsedCommit)
+			}
+		}
+
+		if len(commits) == 0 {
+			continue
+		}
+
+		groups = append(groups, &CommitGroup{
+			CommitType: group.CommitType,
+			Commits:    commits,
+		})
+	}
+
+	return groups
+}
+
+// getScopeGroups groups the commits by scope if enabled, otherwise all commits
+// are returned in a single group without scope
+func (c *CommitParser) getScopeGroups(parsedCommits []*ParsedCommit) []*ScopeGroup {
+	if !c.groupByScope {
+		return []*ScopeGroup{
+			{
+				Commits: parsedCommits,
+			},
+		}
+	}
+
+	return groupByScope(c.scopes, parsedCommits)
+}
+
+// hasOnlyNoIncrementCommits checks if there are parsed commits and all of them
+// have a commit type with increment NONE
+func (c *CommitParser) hasOnlyNoIncrementCommits() bool {
+	if len(c.Breaking) > 0 || len(c.Unknown) > 0 {
+		return false
+	}
+
+	found := false
+
+	for _, group := range c.Groups {
+		if len(group.Commits) == 0 {
+			continue
+		}
+
+		if group.CommitType.GetIncrementLevel() != semver.VersionIncrementLevelNone {
+			return false
+		}
+
+		found = true
+	}
+
+	return found
+}
+
+// GetVersionIncrement returns the version increment caused by all parsed commits
+// (at least BUILD), VersionIncrementLevelNone if all commits have a commit type
+// with increment NONE
+func (c *CommitParser) GetVersionIncrement() *semver.VersionIncrement {
+	if c.hasOnlyNoIncrementCommits() {
+		return semver.NewVersionIncrementNone()
+	}
+
+	versionIncrement := semver.NewVersionIncrement()
+
+	if len(c.Breaking) > 0 {
+		versionIncrement.IncrementMajor()
+	}
+
+	for _, group := range c.Groups {
+		if len(group.Commits) > 0 {
+			versionIncrement.Increment(group.CommitType.GetIncrementLevel())
+		}
+	}
+
+	if len(c.Unknown) > 0 {
+		versionIncrement.IncrementBuild()
+	}
+
+	return versionIncrement
+}
+
+// GetVersionIncrementReasons returns all parsed commits causing the version
+// increment returned by GetVersionIncrement (none if there is no increment)
+func (c *CommitParser) GetVersionIncrementReasons() []*ParsedCommit {
+	level := c.GetVersionIncrement().GetLevel()
+	reasons := []*ParsedCommit{}
+
+	if level == semver.VersionIncrementLevelNone {
+		return reasons
+	}
+
+	for _, parsedCommit := range c.Breaking {
+		if parsedCommit.GetIncrementLevel() == level {
+			reasons = append(reasons, parsedCommit)
+		}
+	}
+
+	for _, group := range c.Groups {
+		for _, parsedCommit := range group.Commits {
+			if parsedCommit.GetIncrementLevel() == level {
+				reasons = append(reasons, parsedCommit)
+			}
+		}
+	}
+
+	for _, parsedCommit := range c.Unknown {
+		if parsedCommit.GetIncrementLevel() == level {
+			reasons = append(reasons, parsedCommit)
+		}
+	}
+
+	return reasons
+}
+
+// NewCommitParser creates a commit parser for the (parsed) commit types
+func NewCommitParser(commitTypes []*CommitType) *CommitParser {
+	groups := []*CommitGroup{}
+	for _, commitType := range commitTypes {
+		groups = append(groups, &CommitGroup{
+			CommitType: commitType,
+			Commits:    []*ParsedCommit{},
+		})
+	}
+
+	return &CommitParser{
+		commitTypes:   commitTypes,
+		issuePatterns: DefaultIssuePatterns,
+		logger:        semver.GetDefaultLogger(),
+		Groups:        groups,
+	}
+}
+
+// SetIssuePatterns sets the (parsed) issue patterns detecting issue
+// references, DefaultIssuePatterns are used if empty
+func (c *CommitParser) SetIssuePatterns(issuePatterns []*IssuePattern) {
+	if len(issuePatterns) == 0 {
+		issuePatterns = DefaultIssuePatterns
+	}
+
+	c.issuePatterns = issuePatterns
+}
+
+// SetMailmap sets the mailmap normalizing the identities of authors,
+// committers and co-authors
+func (c *CommitParser) SetMailmap(mailmap *Mailmap) {
+	c.mailmap = mailmap
+}
+
+// SetContributors enables the 'Contributors' section of changelogs,
+// firstContributions maps the keys of all known identities to the hash of
+// their first commit (see GetFirstContributions)
+func (c *CommitParser) SetContributors(show bool, firstContributions map[string]string) {
+	c.showContributors = show
+	c.firstContributions = firstContributions
+}
+
+// SetScopes sets the (parsed) scopes replacing aliases and labeling scopes in
+// changelogs, commits of the hidden scopes are omitted from changelogs (but
+// still cause version increments)
+func (c *CommitParser) SetScopes(scopes []*Scope, hiddenScopes []string) {
+	c.scopes = scopes
+	c.hiddenScopes = hiddenScopes
+}
+
+// SetGroupByScope enables grouping the commits of each changelog section by
+// scope (in order of the configured scopes)
+func (c *CommitParser) SetGroupByScope(groupByScope bool) {
+	c.groupByScope = groupByScope
+}
+
+// SetLogger sets the logger receiving the classification of parsed commits
+func (c *CommitParser) SetLogger(logger semver.Logger) {
+	c.logger = semver.GetLogger(logger)
+}
@@ -0,0 +1,357 @@
+package changelog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/indece-official/semantic-version/pkg/semver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCommitMessage(t *testing.T) {
+	parsedCommits := ParseCommitMessage("feat(api)!: Changed API model to v2\n\nThe old model is gone.\n\nReviewed-by: Z\nRefs #133\n", "abc")
+	assert.Len(t, parsedCommits, 1)
+
+	parsedCommit := parsedCommits[0]
+	assert.Equal(t, "feat", parsedCommit.Type)
+	assert.Equal(t, "api", parsedCommit.Scope)
+	assert.True(t, parsedCommit.Breaking)
+	assert.Equal(t, "Changed API model to v2", parsedCommit.Message)
+	assert.Equal(t, "The old model is gone.", parsedCommit.Body)
+	assert.Len(t, parsedCommit.Footers, 2)
+	assert.Equal(t, "Reviewed-by", parsedCommit.Footers[0].Token)
+	assert.Equal(t, "Z", parsedCommit.Footers[0].Value)
+	assert.Equal(t, "Refs", parsedCommit.Footers[1].Token)
+	assert.Equal(t, "#133", parsedCommit.Footers[1].Value)
+	assert.Equal(t, "abc", parsedCommit.Hash)
+}
+
+func TestParseCommitMessageBreakingChangeFooter(t *testing.T) {
+	parsedCommits := ParseCommitMessage("refactor: Drop support for Node 6\n\nBREAKING CHANGE: use JavaScript features\n  not available in Node 6.", "abc")
+	assert.Len(t, parsedCommits, 1)
+
+	parsedCommit := parsedCommits[0]
+	assert.Equal(t, "refactor", parsedCommit.Type)
+	assert.True(t, parsedCommit.Breaking)
+	assert.Equal(t, "", parsedCommit.Body)
+	assert.Equal(t, []string{"use JavaScript features\nnot available in Node 6."}, parsedCommit.GetBreakingChangeNotes())
+}
+
+func TestParseCommitMessageMultipleChanges(t *testing.T) {
+	parsedCommits := ParseCommitMessage("break: Changed API model to v2; feat: Added new delete() function; fix: a; b;", "abc")
+	assert.Len(t, parsedCommits, 3)
+
+	assert.Equal(t, "break", parsedCommits[0].Type)
+	assert.False(t, parsedCommits[0].Breaking)
+	assert.Equal(t, "feat", parsedCommits[1].Type)
+	assert.Equal(t, "Added new delete() function", parsedCommits[1].Message)
+	assert.Equal(t, "fix", parsedCommits[2].Type)
+	assert.Equal(t, "a; b", parsedCommits[2].Message)
+}
+
+func TestParseCommitMessageUnknown(t *testing.T) {
+	parsedCommits := ParseCommitMessage("Merge branch 'feat/test' into master", "abc")
+	assert.Len(t, parsedCommits, 1)
+	assert.Equal(t, "", parsedCommits[0].Type)
+	assert.False(t, parsedCommits[0].Breaking)
+	assert.Equal(t, "Merge branch 'feat/test' into master", parsedCommits[0].Message)
+}
+
+func newTestCommitTypes(t *testing.T) []*CommitType {
+	for _, commitType := range DefaultCommitTypes {
+		err := commitType.Parse()
+		assert.NoError(t, err)
+	}
+
+	return DefaultCommitTypes
+}
+
+func TestCommitParserGetVersionIncrement(t *testing.T) {
+	commits := []*object.Commit{
+		{Hash: plumbing.NewHash("01"), Message: "docs: Updated README.md"},
+		{Hash: plumbing.NewHash("02"), Message: "perf(db): Added index"},
+		{Hash: plumbing.NewHash("03"), Message: "Some change"},
+	}
+
+	commitParser := NewCommitParser(newTestCommitTypes(t))
+	commitParser.Parse(commits)
+
+	assert.Len(t, commitParser.Breaking, 0)
+	assert.Len(t, commitParser.GetGroup(FindCommitType(commitParser.commitTypes, "perf")).Commits, 1)
+	assert.Len(t, commitParser.GetGroup(FindCommitType(commitParser.commitTypes, "docs")).Commits, 1)
+	assert.Len(t, commitParser.Unknown, 1)
+	assert.Equal(t, semver.VersionIncrementLevelPatch, commitParser.GetVersionIncrement().GetLevel())
+}
+
+func TestCommitParserBreakingType(t *testing.T) {
+	commits := []*object.Commit{
+		{Hash: plumbing.NewHash("01"), Message: "break: Changed API model to v2; feat: Added new delete() function"},
+	}
+
+	commitParser := NewCommitParser(newTestCommitTypes(t))
+	commitParser.Parse(commits)
+
+	assert.Len(t, commitParser.Breaking, 1)
+	assert.Len(t, commitParser.GetGroup(FindCommitType(commitParser.commitTypes, "feat")).Commits, 1)
+	assert.Equal(t, semver.VersionIncrementLevelMajor, commitParser.GetVersionIncrement().GetLevel())
+	assert.Equal(t, "# BREAKING CHANGES\n"+
+		"* Changed API model to v2 (0100000000000000000000000000000000000000)\n"+
+		"\n"+
+		"# Features\n"+
+		"* Added new delete() function (0100000000000000000000000000000000000000)\n"+
+		"\n", commitParser.GenerateChangelog())
+}
+
+func TestCommitParserCustomCommitTypes(t *testing.T) {
+	commitTypes := []*CommitType{
+		{
+			Type:             "security",
+			Pattern:          "^sec.*",
+			Increment:        "minor",
+			ChangelogHeading: "Security",
+		},
+		{
+			Type:      "deps",
+			Aliases:   []string{"dep"},
+			Increment: "NONE",
+		},
+	}
+
+	for _, commitType := range commitTypes {
+		err := commitType.Parse()
+		assert.NoError(t, err)
+	}
+
+	commits := []*object.Commit{
+		{Hash: plumbing.NewHash("01"), Message: "dep: Bumped go-git"},
+		{Hash: plumbing.NewHash("02"), Message: "sec(auth): Fixed CVE"},
+	}
+
+	commitParser := NewCommitParser(commitTypes)
+	commitParser.Parse(commits)
+
+	assert.Len(t, commitParser.Groups[0].Commits, 1)
+	assert.Len(t, commitParser.Groups[1].Commits, 1)
+	assert.Equal(t, semver.VersionIncrementLevelMinor, commitParser.GetVersionIncrement().GetLevel())
+	assert.Equal(t, "# Security\n* **auth:** Fixed CVE (0200000000000000000000000000000000000000)\n\n", commitParser.GenerateChangelog())
+}
+
+func TestCommitParserGetVersionIncrementNone(t *testing.T) {
+	commitTypes := []*CommitType{
+		{Type: "fix", Increment: "PATCH"},
+		{Type: "deps", Increment: "NONE"},
+	}
+
+	for _, commitType := range commitTypes {
+		err := commitType.Parse()
+		assert.NoError(t, err)
+	}
+
+	// No commits since the release still cause a build increment
+	commitParser := NewCommitParser(commitTypes)
+	commitParser.Parse([]*object.Commit{})
+	assert.Equal(t, semver.VersionIncrementLevelBuild, commitParser.GetVersionIncrement().GetLevel())
+
+	commitParser = NewCommitParser(commitTypes)
+	commitParser.Parse([]*object.Commit{
+		{Hash: plumbing.NewHash("01"), Message: "deps: Bumped go-git"},
+		{Hash: plumbing.NewHash("02"), Message: "deps: Bumped yaml"},
+	})
+	assert.Equal(t, semver.VersionIncrementLevelNone, commitParser.GetVersionIncrement().GetLevel())
+	assert.Empty(t, commitParser.GetVersionIncrementReasons())
+
+	// Commits without a configured type cause a build increment
+	commitParser = NewCommitParser(commitTypes)
+	commitParser.Parse([]*object.Commit{
+		{Hash: plumbing.NewHash("01"), Message: "deps: Bumped go-git"},
+		{Hash: plumbing.NewHash("02"), Message: "Some change"},
+	})
+	assert.Equal(t, semver.VersionIncrementLevelBuild, commitParser.GetVersionIncrement().GetLevel())
+}
+
+func TestCommitTypeParseInvalidIncrement(t *testing.T) {
+	commitType := &CommitType{
+		Type:      "feat",
+		Increment: "HUGE",
+	}
+
+	err := commitType.Parse()
+	assert.Error(t, err)
+}
+
+func TestParseCommitMessageWithIssuePatterns(t *testing.T) {
+	issuePatterns := []*IssuePattern{
+		{
+			Name:    "github",
+			Pattern: `#(?P<id>\d+)`,
+			URL:     "https://github.com/org/repo/issues/{id}",
+		},
+		{
+			Name:    "jira",
+			Pattern: `PROJ-\d+`,
+			URL:     "https://jira.example.com/browse/{issue}",
+		},
+	}
+
+	for _, issuePattern := range issuePatterns {
+		err := issuePattern.Parse()
+		assert.NoError(t, err)
+	}
+
+	parsedCommits := ParseCommitMessageWithIssuePatterns("fix: Fixed PROJ-7 and #12\n\nSee a#3 and org/PROJ-8\n\nRefs: #12, #13\n", "abc", issuePatterns)
+	assert.Len(t, parsedCommits, 1)
+
+	parsedCommit := parsedCommits[0]
+	assert.Equal(t, []string{"PROJ-7", "#12", "#13"}, parsedCommit.Issues)
+	assert.Equal(t, &IssueReference{
+		Issue:   "#12",
+		ID:      "12",
+		Tracker: "github",
+		URL:     "https://github.com/org/repo/issues/12",
+	}, parsedCommit.IssueReferences[1])
+	assert.Equal(t, "https://jira.example.com/browse/PROJ-7", parsedCommit.IssueReferences[0].URL)
+	assert.Equal(t, "PROJ-7", parsedCommit.IssueReferences[0].ID)
+}
+
+func TestIssuePatternParseInvalid(t *testing.T) {
+	issuePattern := &IssuePattern{
+		Name:    "broken",
+		Pattern: `#(\d+`,
+	}
+
+	err := issuePattern.Parse()
+	assert.Error(t, err)
+
+	issuePattern = &IssuePattern{
+		Name: "empty",
+	}
+
+	err = issuePattern.Parse()
+	assert.Error(t, err)
+}
+
+func TestCommitParserIssueLinks(t *testing.T) {
+	issuePatterns := []*IssuePattern{
+		{
+			Name:    "github",
+			Pattern: `#(?P<id>\d+)`,
+			URL:     "https://github.com/org/repo/issues/{id}",
+		},
+	}
+
+	err := issuePatterns[0].Parse()
+	assert.NoError(t, err)
+
+	commits := []*object.Commit{
+		{Hash: plumbing.NewHash("01"), Message: "fix(api): Fixed crash\n\nCloses #45"},
+		{Hash: plumbing.NewHash("02"), Message: "fix: Fixed PROJ-1"},
+	}
+
+	commitParser := NewCommitParser(newTestCommitTypes(t))
+	commitParser.SetIssuePatterns(issuePatterns)
+	commitParser.Parse(commits)
+
+	assert.Equal(t, "# Fixes\n"+
+		"* **api:** Fixed crash (0100000000000000000000000000000000000000), [#45](https://github.com/org/repo/issues/45)\n"+
+		"* Fixed PROJ-1 (0200000000000000000000000000000000000000)\n"+
+		"\n", commitParser.GenerateChangelog())
+}
+
+func TestCommitParserContributors(t *testing.T) {
+	mailmap, err := ParseMailmap("Jane Doe <jane@example.com> <jane@old.example.com>\n")
+	assert.NoError(t, err)
+
+	previousCommit := &object.Commit{
+		Hash:    plumbing.NewHash("01"),
+		Message: "fix: Old fix",
+		Author:  object.Signature{Name: "jane", Email: "jane@old.example.com", When: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	commits := []*object.Commit{
+		{
+			Hash:      plumbing.NewHash("02"),
+			Message:   "feat: Added export\n\nCo-authored-by: Jane <jane@old.example.com>\nCo-authored-by: Alice <alice@example.com>",
+			Author:    object.Signature{Name: "Bob", Email: "bob@example.com", When: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)},
+			Committer: object.Signature{Name: "jane", Email: "jane@old.example.com"},
+		},
+	}
+
+	commitParser := NewCommitParser(newTestCommitTypes(t))
+	commitParser.SetMailmap(mailmap)
+	commitParser.SetContributors(true, GetFirstContributions(append(commits, previousCommit), mailmap))
+	commitParser.Parse(commits)
+
+	parsedCommit := commitParser.Groups[1].Commits[0]
+	assert.Equal(t, "Bob", parsedCommit.Author)
+	assert.Equal(t, "Jane Doe", parsedCommit.Committer)
+	assert.Equal(t, "jane@example.com", parsedCommit.CommitterEmail)
+	assert.Equal(t, []*Identity{
+		{Name: "Jane Doe", Email: "jane@example.com"},
+		{Name: "Alice", Email: "alice@example.com"},
+	}, parsedCommit.CoAuthors)
+
+	assert.Equal(t, "# Features\n"+
+		"* Added export (0200000000000000000000000000000000000000)\n"+
+		"\n"+
+		"# Contributors\n"+
+		"* Alice (first contribution)\n"+
+		"* Bob (first contribution)\n"+
+		"* Jane Doe\n"+
+		"\n", commitParser.GenerateChangelog())
+}
+
+func TestCommitParserGroupByScope(t *testing.T) {
+	scopes := []*Scope{
+		{Name: "auth", Aliases: []string{"login"}, Component: "Authentication"},
+		{Name: "api"},
+	}
+
+	for _, scope := range scopes {
+		err := scope.Parse()
+		assert.NoError(t, err)
+	}
+
+	commits := []*object.Commit{
+		{Hash: plumbing.NewHash("01"), Message: "feat(db): Added index"},
+		{Hash: plumbing.NewHash("02"), Message: "feat(api): Added endpoint"},
+		{Hash: plumbing.NewHash("03"), Message: "feat(Login): Added SSO"},
+		{Hash: plumbing.NewHash("04"), Message: "feat: Added export"},
+		{Hash: plumbing.NewHash("05"), Message: "fix(ci): Fixed pipeline"},
+	}
+
+	commitParser := NewCommitParser(newTestCommitTypes(t))
+	commitParser.SetScopes(scopes, []string{"ci"})
+	commitParser.Parse(commits)
+
+	assert.Equal(t, "auth", commitParser.Groups[1].Commits[2].Scope)
+	assert.Equal(t, "Authentication", commitParser.Groups[1].Commits[2].Component)
+	assert.Equal(t, semver.VersionIncrementLevelMinor, commitParser.GetVersionIncrement().GetLevel())
+
+	assert.Equal(t, "# Features\n"+
+		"* **db:** Added index (0100000000000000000000000000000000000000)\n"+
+		"* **api:** Added endpoint (0200000000000000000000000000000000000000)\n"+
+		"* **Authentication:** Added SSO (0300000000000000000000000000000000000000)\n"+
+		"* Added export (0400000000000000000000000000000000000000)\n"+
+		"\n", commitParser.GenerateChangelog())
+
+	commitParser.SetGroupByScope(true)
+
+	assert.Equal(t, "# Features\n"+
+		"* Added export (0400000000000000000000000000000000000000)\n"+
+		"* **Authentication**\n"+
+		"  * Added SSO (0300000000000000000000000000000000000000)\n"+
+		"* **api**\n"+
+		"  * Added endpoint (0200000000000000000000000000000000000000)\n"+
+		"* **db**\n"+
+		"  * Added index (0100000000000000000000000000000000000000)\n"+
+		"\n", commitParser.GenerateChangelog())
+}
+
+func TestScopeParseMissingName(t *testing.T) {
+	scope := &Scope{Component: "API"}
+
+	err := scope.Parse()
+	assert.Error(t, err)
+}
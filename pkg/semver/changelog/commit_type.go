@@ -0,0 +1,148 @@
+package changelog
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/indece-official/semantic-version/pkg/semver"
+)
+
+// CommitType specifies how commits of a type are treated
+type CommitType struct {
+	// Type is the commit type as used in the commit header (e.g. 'feat')
+	Type string `yaml:"type"`
+	// Aliases are alternative names for the commit type (e.g. 'feature')
+	Aliases []string `yaml:"aliases,omitempty"`
+	// Pattern is an optional regular expression matched against the commit type
+	Pattern string `yaml:"pattern,omitempty"`
+	// Increment is the version increment caused by a commit of this type
+	// Values: MAJOR, MINOR, PATCH, BUILD, NONE
+	Increment string `yaml:"increment"`
+	// ChangelogHeading is the heading of the changelog section listing commits
+	// of this type, commits are not listed in the changelog if empty
+	ChangelogHeading string `yaml:"changelog_heading,omitempty"`
+
+	incrementLevel semver.VersionIncrementLevel
+	exp            *regexp.Regexp
+}
+
+// GetIncrementLevel returns the parsed increment level
+func (t *CommitType) GetIncrementLevel() semver.VersionIncrementLevel {
+	return t.incrementLevel
+}
+
+// Match checks if a (lower-cased) commit type belongs to this commit type
+func (t *CommitType) Match(commitType string) bool {
+	if strings.EqualFold(t.Type, commitType) {
+		return true
+	}
+
+	for _, alias := range t.Aliases {
+		if strings.EqualFold(alias, commitType) {
+			return true
+		}
+	}
+
+	if t.exp != nil && t.exp.MatchString(commitType) {
+		return true
+	}
+
+	return false
+}
+
+// Parse validates the commit type and compiles its pattern
+func (t *CommitType) Parse() error {
+	var err error
+
+	if t.Type == "" {
+		return fmt.Errorf("missing type for commit type")
+	}
+
+	t.incrementLevel, err = semver.ParseVersionIncrementLevel(t.Increment)
+	if err != nil {
+		return fmt.Errorf("invalid increment for commit type \"%s\": %s", t.Type, err)
+	}
+
+	t.exp = nil
+	if t.Pattern != "" {
+		t.exp, err = regexp.Compile(t.Pattern)
+		if err != nil {
+			return fmt.Errorf("can't parse pattern for commit type \"%s\": %s", t.Type, err)
+		}
+	}
+
+	return nil
+}
+
+// FindCommitType returns the first commit type matching the given type or nil
+func FindCommitType(commitTypes []*CommitType, commitType string) *CommitType {
+	if commitType == "" {
+		return nil
+	}
+
+	for _, t := range commitTypes {
+		if t.Match(commitType) {
+			return t
+		}
+	}
+
+	return nil
+}
+
+// DefaultCommitTypes are used if no commit types are configured
+var DefaultCommitTypes = []*CommitType{
+	{
+		Type:      "break",
+		Aliases:   []string{"breaking"},
+		Increment: "MAJOR",
+	},
+	{
+		Type:             "feat",
+		Aliases:          []string{"feature"},
+		Increment:        "MINOR",
+		ChangelogHeading: "Features",
+	},
+	{
+		Type:             "fix",
+		Increment:        "PATCH",
+		ChangelogHeading: "Fixes",
+	},
+	{
+		Type:             "perf",
+		Increment:        "PATCH",
+		ChangelogHeading: "Performance Improvements",
+	},
+	{
+		Type:      "build",
+		Increment: "BUILD",
+	},
+	{
+		Type:      "chore",
+		Increment: "BUILD",
+	},
+	{
+		Type:      "ci",
+		Increment: "BUILD",
+	},
+	{
+		Type:      "docs",
+		Increment: "BUILD",
+	},
+	{
+		Type:      "refactor",
+		Increment: "BUILD",
+	},
+	{
+		Type:      "revert",
+		Increment: "BUILD",
+	},
+	{
+		Type:      "style",
+		Increment: "BUILD",
+	},
+	{
+		Type:      "test",
+		Increment: "BUILD",
+	},
+}
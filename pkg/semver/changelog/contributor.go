@@ -0,0 +1,160 @@
+package changelog
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// expIdentity matches an identity like 'Jane Doe <jane@example.com>'
+var expIdentity = regexp.MustCompile(`^([^<>]*?)\s*<([^<>]*)>$`)
+
+// Identity is the name and email of an author
+type Identity struct {
+	Name  string `json:"name" yaml:"name"`
+	Email string `json:"email" yaml:"email"`
+}
+
+// Key returns the lower-cased email identifying the author, the lower-cased
+// name if the email is empty
+func (i *Identity) Key() string {
+	if i.Email != "" {
+		return strings.ToLower(i.Email)
+	}
+
+	return strings.ToLower(i.Name)
+}
+
+// ParseIdentity parses an identity like 'Jane Doe <jane@example.com>', nil
+// is returned if the value has no email
+func ParseIdentity(value string) *Identity {
+	match := expIdentity.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return nil
+	}
+
+	return &Identity{
+		Name:  match[1],
+		Email: strings.TrimSpace(match[2]),
+	}
+}
+
+// CoAuthorToken is the footer token of co-authors
+const CoAuthorToken = "Co-authored-by"
+
+// GetCommitContributors returns the author and all co-authors ('Co-authored-by'
+// footers) of a commit normalized by the mailmap (may be nil), the author is
+// always the first identity
+func GetCommitContributors(commit *object.Commit, mailmap *Mailmap) []*Identity {
+	author := &Identity{}
+	author.Name, author.Email = mailmap.Resolve(commit.Author.Name, commit.Author.Email)
+
+	identities := []*Identity{author}
+	seen := map[string]bool{
+		author.Key(): true,
+	}
+
+	lines := strings.Split(strings.TrimSpace(commit.Message), "\n")
+	_, footers := splitBodyAndFooters(lines[1:])
+
+	for _, footer := range footers {
+		if !strings.EqualFold(footer.Token, CoAuthorToken) {
+			continue
+		}
+
+		identity := ParseIdentity(footer.Value)
+		if identity == nil {
+			continue
+		}
+
+		identity.Name, identity.Email = mailmap.Resolve(identity.Name, identity.Email)
+		if seen[identity.Key()] {
+			continue
+		}
+
+		seen[identity.Key()] = true
+		identities = append(identities, identity)
+	}
+
+	return identities
+}
+
+// Contributor is an author or co-author of changes in a changelog
+type Contributor struct {
+	Name  string `json:"name" yaml:"name"`
+	Email string `json:"email" yaml:"email"`
+	// FirstTime is true if the changes contain the first contribution
+	FirstTime bool `json:"first_time" yaml:"first_time"`
+}
+
+// GetContributors returns all authors and co-authors of the parsed commits
+// (including commits without changelog heading) sorted by name
+func (c *CommitParser) GetContributors() []*Contributor {
+	contributors := []*Contributor{}
+	contributorsByKey := map[string]*Contributor{}
+
+	parsedCommits := []*ParsedCommit{}
+	parsedCommits = append(parsedCommits, c.Breaking...)
+	for _, group := range c.Groups {
+		parsedCommits = append(parsedCommits, group.Commits...)
+	}
+	parsedCommits = append(parsedCommits, c.Unknown...)
+
+	for _, parsedCommit := range parsedCommits {
+		identities := []*Identity{{Name: parsedCommit.Author, Email: parsedCommit.AuthorEmail}}
+		identities = append(identities, parsedCommit.CoAuthors...)
+
+		for _, identity := range identities {
+			if identity.Key() == "" {
+				continue
+			}
+
+			contributor, exists := contributorsByKey[identity.Key()]
+			if !exists {
+				contributor = &Contributor{
+					Name:  identity.Name,
+					Email: identity.Email,
+				}
+
+				contributorsByKey[identity.Key()] = contributor
+				contributors = append(contributors, contributor)
+			}
+
+			hash, exists := c.firstContributions[identity.Key()]
+			if exists && hash == parsedCommit.Hash {
+				contributor.FirstTime = true
+			}
+		}
+	}
+
+	sort.SliceStable(contributors, func(i, j int) bool {
+		return strings.ToLower(contributors[i].Name) < strings.ToLower(contributors[j].Name)
+	})
+
+	return contributors
+}
+
+// GetFirstContributions returns the hash of the first (oldest authored) commit
+// of each author and co-author of the commits by the key of their identity,
+// commits are expected newest first (commits with the same date are ordered
+// by their position)
+func GetFirstContributions(commits []*object.Commit, mailmap *Mailmap) map[string]string {
+	firstContributions := map[string]string{}
+	firstCommits := map[string]*object.Commit{}
+
+	for _, commit := range commits {
+		for _, identity := range GetCommitContributors(commit, mailmap) {
+			firstCommit, exists := firstCommits[identity.Key()]
+			if exists && commit.Author.When.After(firstCommit.Author.When) {
+				continue
+			}
+
+			firstCommits[identity.Key()] = commit
+			firstContributions[identity.Key()] = commit.Hash.String()
+		}
+	}
+
+	return firstContributions
+}
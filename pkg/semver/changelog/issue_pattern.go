@@ -0,0 +1,146 @@
+package changelog
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// IssuePattern specifies how references to an issue tracker are detected in
+// commit messages and linked in changelogs
+type IssuePattern struct {
+	// Name is the name of the issue tracker (e.g. 'jira')
+	Name string `yaml:"name"`
+	// Pattern is a regular expression matching an issue reference (e.g.
+	// '#(?P<id>\d+)'), the optional group named 'id' is the issue id
+	Pattern string `yaml:"pattern"`
+	// URL is the template of links to the issue (placeholders: {issue} for the
+	// reference, {id} for the issue id), references are not linked if empty
+	URL string `yaml:"url,omitempty"`
+
+	exp *regexp.Regexp
+}
+
+// Parse validates the issue pattern and compiles it
+func (p *IssuePattern) Parse() error {
+	var err error
+
+	if p.Name == "" {
+		return fmt.Errorf("missing name for issue pattern")
+	}
+
+	if p.Pattern == "" {
+		return fmt.Errorf("missing pattern for issue pattern \"%s\"", p.Name)
+	}
+
+	// References must not be part of a word or a path (e.g. 'a#1' or 'org/PROJ-1')
+	p.exp, err = regexp.Compile(`(?:^|[^\w/])(?P<issue>` + p.Pattern + `)\b`)
+	if err != nil {
+		return fmt.Errorf("can't parse pattern for issue pattern \"%s\": %s", p.Name, err)
+	}
+
+	return nil
+}
+
+// FormatURL returns the link to an issue, empty if no url is configured
+func (p *IssuePattern) FormatURL(issue string, id string) string {
+	if p.URL == "" {
+		return ""
+	}
+
+	str := strings.ReplaceAll(p.URL, "{issue}", issue)
+	str = strings.ReplaceAll(str, "{id}", id)
+
+	return str
+}
+
+// IssueReference is a reference to an issue found in a commit message
+type IssueReference struct {
+	// Issue is the reference as written in the commit message (e.g. '#123')
+	Issue string
+	// ID is the issue id (e.g. '123'), same as Issue if the pattern has no id
+	ID string
+	// Tracker is the name of the issue pattern
+	Tracker string
+	// URL is the link to the issue, empty if no url is configured
+	URL string
+}
+
+// FormatMarkdown returns the reference as markdown link, the plain reference
+// if it has no url
+func (r *IssueReference) FormatMarkdown() string {
+	if r.URL == "" {
+		return r.Issue
+	}
+
+	return fmt.Sprintf("[%s](%s)", r.Issue, r.URL)
+}
+
+// DefaultIssuePatterns is used if no issue patterns are configured, matches
+// references like '#123' (keys like 'PROJ-123' must be configured, otherwise
+// tokens like 'UTF-8' would be issues)
+var DefaultIssuePatterns = []*IssuePattern{
+	{
+		Name:    "github",
+		Pattern: `#(?P<id>\d+)`,
+	},
+}
+
+func init() {
+	for _, issuePattern := range DefaultIssuePatterns {
+		err := issuePattern.Parse()
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+// FindIssueReferences returns all unique issue references in the texts
+// matching one of the (parsed) issue patterns
+func FindIssueReferences(issuePatterns []*IssuePattern, texts ...string) []*IssueReference {
+	references := []*IssueReference{}
+	seen := map[string]bool{}
+
+	for _, text := range texts {
+		// Position of the references in the text
+		positions := map[*IssueReference]int{}
+		textReferences := []*IssueReference{}
+
+		for _, issuePattern := range issuePatterns {
+			issueIndex := issuePattern.exp.SubexpIndex("issue")
+			idIndex := issuePattern.exp.SubexpIndex("id")
+
+			for _, match := range issuePattern.exp.FindAllStringSubmatchIndex(text, -1) {
+				issue := text[match[2*issueIndex]:match[2*issueIndex+1]]
+				if seen[issue] {
+					continue
+				}
+
+				id := issue
+				if idIndex >= 0 && match[2*idIndex] >= 0 {
+					id = text[match[2*idIndex]:match[2*idIndex+1]]
+				}
+
+				reference := &IssueReference{
+					Issue:   issue,
+					ID:      id,
+					Tracker: issuePattern.Name,
+					URL:     issuePattern.FormatURL(issue, id),
+				}
+
+				seen[issue] = true
+				positions[reference] = match[2*issueIndex]
+				textReferences = append(textReferences, reference)
+			}
+		}
+
+		sort.SliceStable(textReferences, func(i, j int) bool {
+			return positions[textReferences[i]] < positions[textReferences[j]]
+		})
+
+		references = append(references, textReferences...)
+	}
+
+	return references
+}
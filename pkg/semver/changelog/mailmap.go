@@ -0,0 +1,116 @@
+package changelog
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+)
+
+// expMailmapLine matches a line of a mailmap file:
+//
+//	Proper Name <commit@email>
+//	<proper@email> <commit@email>
+//	Proper Name <proper@email> <commit@email>
+//	Proper Name <proper@email> Commit Name <commit@email>
+var expMailmapLine = regexp.MustCompile(`^([^<]*)<([^>]*)>(?:([^<]*)<([^>]*)>)?`)
+
+// mailmapEntry maps a commit identity to the proper identity
+type mailmapEntry struct {
+	properName  string
+	properEmail string
+	// commitName is empty if the entry matches all names of commitEmail
+	commitName  string
+	commitEmail string
+}
+
+// Mailmap normalizes the names and emails of authors like git's .mailmap
+// (see https://git-scm.com/docs/gitmailmap)
+type Mailmap struct {
+	entries []*mailmapEntry
+}
+
+// Resolve returns the proper name and email of an identity, the identity
+// itself if no entry matches
+func (m *Mailmap) Resolve(name string, email string) (string, string) {
+	if m == nil {
+		return name, email
+	}
+
+	var match *mailmapEntry
+
+	for _, entry := range m.entries {
+		if !strings.EqualFold(entry.commitEmail, email) {
+			continue
+		}
+
+		if entry.commitName != "" && !strings.EqualFold(entry.commitName, name) {
+			continue
+		}
+
+		// Entries with a commit name take precedence, otherwise the last entry wins
+		if match != nil && match.commitName != "" && entry.commitName == "" {
+			continue
+		}
+
+		match = entry
+	}
+
+	if match == nil {
+		return name, email
+	}
+
+	if match.properName != "" {
+		name = match.properName
+	}
+
+	if match.properEmail != "" {
+		email = match.properEmail
+	}
+
+	return name, email
+}
+
+// ParseMailmap parses the content of a mailmap file
+func ParseMailmap(text string) (*Mailmap, error) {
+	mailmap := &Mailmap{
+		entries: []*mailmapEntry{},
+	}
+
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		match := expMailmapLine.FindStringSubmatch(line)
+		if match == nil {
+			return nil, fmt.Errorf("invalid mailmap line %d: %s", i+1, line)
+		}
+
+		entry := &mailmapEntry{
+			properName:  strings.TrimSpace(match[1]),
+			commitEmail: strings.TrimSpace(match[2]),
+		}
+
+		if match[4] != "" {
+			entry.properEmail = entry.commitEmail
+			entry.commitName = strings.TrimSpace(match[3])
+			entry.commitEmail = strings.TrimSpace(match[4])
+		}
+
+		mailmap.entries = append(mailmap.entries, entry)
+	}
+
+	return mailmap, nil
+}
+
+// LoadMailmap loads a mailmap file
+func LoadMailmap(filename string) (*Mailmap, error) {
+	text, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("can't read mailmap %s: %s", filename, err)
+	}
+
+	return ParseMailmap(string(text))
+}
@@ -0,0 +1,47 @@
+package changelog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMailmapResolve(t *testing.T) {
+	mailmap, err := ParseMailmap("# Team\n" +
+		"Jane Doe <jane@example.com>\n" +
+		"<bob@example.com> <bob@old.example.com>\n" +
+		"Robert <robert@example.com> Bobby <BOB@home.example.com> # Nickname\n" +
+		"Bob <bob@example.com> <bob@home.example.com>\n")
+	assert.NoError(t, err)
+
+	name, email := mailmap.Resolve("jane", "Jane@Example.com")
+	assert.Equal(t, "Jane Doe", name)
+	assert.Equal(t, "Jane@Example.com", email)
+
+	name, email = mailmap.Resolve("Bob", "bob@old.example.com")
+	assert.Equal(t, "Bob", name)
+	assert.Equal(t, "bob@example.com", email)
+
+	name, email = mailmap.Resolve("bobby", "bob@home.example.com")
+	assert.Equal(t, "Robert", name)
+	assert.Equal(t, "robert@example.com", email)
+
+	name, email = mailmap.Resolve("B.", "bob@home.example.com")
+	assert.Equal(t, "Bob", name)
+	assert.Equal(t, "bob@example.com", email)
+
+	name, email = mailmap.Resolve("Alice", "alice@example.com")
+	assert.Equal(t, "Alice", name)
+	assert.Equal(t, "alice@example.com", email)
+
+	var nilMailmap *Mailmap
+
+	name, email = nilMailmap.Resolve("Alice", "alice@example.com")
+	assert.Equal(t, "Alice", name)
+	assert.Equal(t, "alice@example.com", email)
+}
+
+func TestParseMailmapInvalid(t *testing.T) {
+	_, err := ParseMailmap("Jane Doe jane@example.com\n")
+	assert.Error(t, err)
+}
@@ -0,0 +1,131 @@
+package changelog
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Scope specifies how changes of a scope are listed in changelogs
+type Scope struct {
+	// Name is the scope as used in the commit header (e.g. 'auth')
+	Name string `yaml:"name"`
+	// Aliases are alternative names for the scope (e.g. 'authentication')
+	Aliases []string `yaml:"aliases,omitempty"`
+	// Component is the label of the scope in changelogs (default: Name)
+	Component string `yaml:"component,omitempty"`
+}
+
+// Match checks if a scope of a commit belongs to this scope (case-insensitive)
+func (s *Scope) Match(scope string) bool {
+	if strings.EqualFold(s.Name, scope) {
+		return true
+	}
+
+	for _, alias := range s.Aliases {
+		if strings.EqualFold(alias, scope) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Parse validates the scope
+func (s *Scope) Parse() error {
+	if s.Name == "" {
+		return fmt.Errorf("missing name for scope")
+	}
+
+	if s.Component == "" {
+		s.Component = s.Name
+	}
+
+	return nil
+}
+
+// FindScope returns the first (parsed) scope matching the given scope or nil
+func FindScope(scopes []*Scope, scope string) *Scope {
+	if scope == "" {
+		return nil
+	}
+
+	for _, s := range scopes {
+		if s.Match(scope) {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// ScopeGroup contains the parsed commits of a scope
+type ScopeGroup struct {
+	// Scope is the name of the scope, empty for commits without scope
+	Scope string
+	// Component is the label of the scope
+	Component string
+	Commits   []*ParsedCommit
+}
+
+// groupByScope groups the parsed commits by their scope, commits without
+// scope come first followed by the configured scopes (in order of config)
+// and all other scopes (sorted)
+func groupByScope(scopes []*Scope, parsedCommits []*ParsedCommit) []*ScopeGroup {
+	groups := []*ScopeGroup{}
+	groupsByScope := map[string]*ScopeGroup{}
+
+	for _, parsedCommit := range parsedCommits {
+		group, exists := groupsByScope[parsedCommit.Scope]
+		if !exists {
+			group = &ScopeGroup{
+				Scope:     parsedCommit.Scope,
+				Component: parsedCommit.Component,
+				Commits:   []*ParsedCommit{},
+			}
+
+			groupsByScope[parsedCommit.Scope] = group
+			groups = append(groups, group)
+		}
+
+		group.Commits = append(group.Commits, parsedCommit)
+	}
+
+	position := func(group *ScopeGroup) int {
+		if group.Scope == "" {
+			return -1
+		}
+
+		for i, scope := range scopes {
+			if scope.Name == group.Scope {
+				return i
+			}
+		}
+
+		return len(scopes)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		positionI := position(groups[i])
+		positionJ := position(groups[j])
+
+		if positionI != positionJ {
+			return positionI < positionJ
+		}
+
+		return groups[i].Scope < groups[j].Scope
+	})
+
+	return groups
+}
+
+// isHiddenScope checks if commits of the scope are omitted from changelogs
+func isHiddenScope(hiddenScopes []string, scope string) bool {
+	for _, hiddenScope := range hiddenScopes {
+		if strings.EqualFold(hiddenScope, scope) {
+			return true
+		}
+	}
+
+	return false
+}
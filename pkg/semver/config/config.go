@@ -0,0 +1,951 @@
+// Package config contains the configuration (semanticversion.yaml).
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/indece-official/semantic-version/pkg/semver"
+	"github.com/indece-official/semantic-version/pkg/semver/changelog"
+	"github.com/indece-official/semantic-version/pkg/semver/pattern"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultFilename is the default filename of the config file
+const DefaultFilename = "./semanticversion.yaml"
+
+// BuildCounter specifies how the build number of new versions is determined
+type BuildCounter string
+
+const (
+	// BuildCounterReset resets the build number on every version increment
+	// and only increments it to avoid collisions with existing tags
+	BuildCounterReset BuildCounter = "RESET"
+	// BuildCounterContinue continues the build number of the highest existing
+	// tag with the same version, release channel and branch
+	BuildCounterContinue BuildCounter = "CONTINUE"
+)
+
+// BranchConfig is the configuration for all branches matching BranchPattern
+type BranchConfig struct {
+	BranchPattern string `yaml:"branch_pattern"`
+
+	// VersionPattern specifies the pattern to generate new version numbers
+	// Placeholders:
+	//   {major} Major version
+	//   {minor} Minor version
+	//   {path} Path version
+	//   {branch} Branch name
+	//   {commit} Commit hash (short)
+	//   {build} Build number
+	//   {qualifier} Qualifier of the release channel (e.g. -beta, -SNAPSHOT)
+	VersionPattern string                `yaml:"version_pattern"`
+	ReleaseChannel semver.ReleaseChannel `yaml:"release_channel"`
+	// VersionScheme specifies the parsing, qualifiers and ordering of versions
+	// (SEMVER, MAVEN, PEP440, CALVER, NUGET, DEBIAN or a registered custom
+	// scheme, default SEMVER), with MAVEN the version pattern defaults to
+	// '{major}.{minor}.{patch}' for FINAL and '{major}.{minor}.{patch}-SNAPSHOT'
+	// for all other channels
+	VersionScheme string `yaml:"version_scheme,omitempty"`
+	// BuildCounter specifies how the build number is determined (default RESET)
+	BuildCounter BuildCounter `yaml:"build_counter,omitempty"`
+
+	branchPattern  *pattern.BranchPattern
+	versionPattern *pattern.VersionPattern
+}
+
+func (c *BranchConfig) GetBranchPattern() *pattern.BranchPattern {
+	return c.branchPattern
+}
+
+func (c *BranchConfig) GetVersionPattern() *pattern.VersionPattern {
+	return c.versionPattern
+}
+
+// GetVersionScheme returns the version scheme of the branch, nil if the
+// scheme is unknown
+func (c *BranchConfig) GetVersionScheme() semver.VersionScheme {
+	return semver.GetVersionScheme(c.VersionScheme)
+}
+
+// getVersionPattern returns the version pattern or the default pattern of
+// the version scheme if none is set
+func (c *BranchConfig) getVersionPattern() string {
+	if c.VersionPattern != "" || c.VersionScheme != semver.VersionSchemeMaven {
+		return c.VersionPattern
+	}
+
+	if c.ReleaseChannel == semver.ReleaseChannelFinal {
+		return "{major}.{minor}.{patch}"
+	}
+
+	return "{major}.{minor}.{patch}-SNAPSHOT"
+}
+
+// Parse validates the branch config and compiles its patterns using the
+// defined release channels
+func (c *BranchConfig) Parse(releaseChannels *semver.ReleaseChannels) error {
+	var err error
+
+	if !releaseChannels.IsDefined(c.ReleaseChannel) {
+		return fmt.Errorf("invalid release channel for branch \"%s\": %s", c.BranchPattern, c.ReleaseChannel)
+	}
+
+	if c.GetVersionScheme() == nil {
+		return fmt.Errorf("invalid version scheme for branch \"%s\": %s", c.BranchPattern, c.VersionScheme)
+	}
+
+	if c.BuildCounter == "" {
+		c.BuildCounter = BuildCounterReset
+	}
+
+	if c.BuildCounter != BuildCounterReset &&
+		c.BuildCounter != BuildCounterContinue {
+		return fmt.Errorf("invalid build counter for branch \"%s\": %s", c.BranchPattern, c.BuildCounter)
+	}
+
+	c.VersionPattern = c.getVersionPattern()
+
+	c.branchPattern, err = pattern.NewBranchPattern(c.BranchPattern)
+	if err != nil {
+		return fmt.Errorf("can't parse branch pattern \"%s\": %s", c.BranchPattern, err)
+	}
+
+	c.versionPattern, err = pattern.NewSchemeVersionPattern(c.VersionPattern, c.ReleaseChannel, c.GetVersionScheme(), releaseChannels)
+	if err != nil {
+		return fmt.Errorf("can't parse version pattern \"%s\": %s", c.VersionPattern, err)
+	}
+
+	return nil
+}
+
+// withTagPrefix returns a copy of the branch config with the tag prefix
+// prepended to the version pattern
+func (c *BranchConfig) withTagPrefix(tagPrefix string) *BranchConfig {
+	branchConfig := *c
+	branchConfig.VersionPattern = tagPrefix + c.getVersionPattern()
+
+	return &branchConfig
+}
+
+// BumpFileType specifies the format of a file the version is written to
+type BumpFileType string
+
+const (
+	BumpFileTypeJSON  BumpFileType = "JSON"
+	BumpFileTypeYAML  BumpFileType = "YAML"
+	BumpFileTypeXML   BumpFileType = "XML"
+	BumpFileTypeTOML  BumpFileType = "TOML"
+	BumpFileTypeRegex BumpFileType = "REGEX"
+)
+
+// BumpFileConfig is the configuration of a file the version is written to
+// by the bump-files command
+type BumpFileConfig struct {
+	File string       `yaml:"file"`
+	Type BumpFileType `yaml:"type"`
+	// Path is the location of the version: keys separated by '.' (JSON, YAML,
+	// TOML) or elements separated by '/' (XML)
+	Path string `yaml:"path,omitempty"`
+	// Pattern is a regular expression (REGEX), the group named 'version' or
+	// the first group of the first match is replaced by the version
+	Pattern string `yaml:"pattern,omitempty"`
+	// VersionPattern specifies the written version (placeholders like the
+	// version pattern of branches), defaults to the version tag without
+	// prefix (e.g. '1.2.0' for 'v1.2.0')
+	VersionPattern string `yaml:"version_pattern,omitempty"`
+}
+
+// Parse validates the bump file config, types without built-in updater are
+// accepted for custom updaters
+func (c *BumpFileConfig) Parse() error {
+	if c.File == "" {
+		return fmt.Errorf("missing file")
+	}
+
+	switch c.Type {
+	case "":
+		return fmt.Errorf("missing type of file %s", c.File)
+	case BumpFileTypeJSON, BumpFileTypeYAML, BumpFileTypeXML, BumpFileTypeTOML:
+		if c.Path == "" {
+			return fmt.Errorf("missing path of file %s", c.File)
+		}
+	case BumpFileTypeRegex:
+		exp, err := regexp.Compile(c.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern of file %s: %s", c.File, err)
+		}
+
+		if exp.NumSubexp() == 0 {
+			return fmt.Errorf("pattern of file %s contains no group", c.File)
+		}
+	}
+
+	return nil
+}
+
+// DependencyIncrement specifies the version increment of a project caused by
+// a changed dependency
+type DependencyIncrement string
+
+const (
+	// DependencyIncrementPatch increments the patch version
+	DependencyIncrementPatch DependencyIncrement = "PATCH"
+	// DependencyIncrementMinor increments the minor version
+	DependencyIncrementMinor DependencyIncrement = "MINOR"
+	// DependencyIncrementSame increments the same level as the dependency
+	DependencyIncrementSame DependencyIncrement = "SAME"
+)
+
+// ProjectConfig is the configuration of an independently versioned project
+// in a monorepo
+type ProjectConfig struct {
+	Name string `yaml:"name"`
+	// Path is the directory of the project, only commits changing files
+	// below this path are considered
+	Path string `yaml:"path"`
+	// TagPrefix is prepended to the version patterns of all branches of the
+	// project (e.g. 'api/')
+	TagPrefix string `yaml:"tag_prefix,omitempty"`
+	// Branches are the branch configs of the project (default: branch configs
+	// of the root config)
+	Branches []*BranchConfig `yaml:"branches,omitempty"`
+	// DependsOn contains the names of all projects the project depends on
+	DependsOn []string `yaml:"depends_on,omitempty"`
+	// DependencyIncrement is the version increment caused by a changed
+	// dependency (default PATCH)
+	DependencyIncrement DependencyIncrement `yaml:"dependency_increment,omitempty"`
+	// BumpFiles are the files the version is written to (paths relative to
+	// the project's path)
+	BumpFiles []*BumpFileConfig `yaml:"bump_files,omitempty"`
+
+	branches []*BranchConfig
+}
+
+// GetDependencyIncrement returns the version increment of the project caused
+// by a dependency with the given version increment
+//
+// Only dependencies with at least a patch increment cause an increment.
+func (c *ProjectConfig) GetDependencyIncrement(level semver.VersionIncrementLevel) semver.VersionIncrementLevel {
+	if level < semver.VersionIncrementLevelPatch {
+		return semver.VersionIncrementLevelNone
+	}
+
+	switch c.DependencyIncrement {
+	case DependencyIncrementMinor:
+		return semver.VersionIncrementLevelMinor
+	case DependencyIncrementSame:
+		return level
+	default:
+		return semver.VersionIncrementLevelPatch
+	}
+}
+
+// GetBranches returns the parsed branch configs of the project including the
+// tag prefix
+func (c *ProjectConfig) GetBranches() []*BranchConfig {
+	return c.branches
+}
+
+// GetPath returns the cleaned path of the project, empty for the repository root
+func (c *ProjectConfig) GetPath() string {
+	projectPath := path.Clean(strings.ReplaceAll(c.Path, "\\", "/"))
+	if projectPath == "." || projectPath == "/" {
+		return ""
+	}
+
+	return strings.TrimPrefix(projectPath, "/")
+}
+
+// ContainsFile checks if a file (path relative to the repository root) is
+// located below the path of the project
+func (c *ProjectConfig) ContainsFile(filename string) bool {
+	projectPath := c.GetPath()
+
+	return projectPath == "" ||
+		filename == projectPath ||
+		strings.HasPrefix(filename, projectPath+"/")
+}
+
+// Parse validates the project config and parses its branch configs, the
+// default branch configs are used if the project has no own branch configs
+func (c *ProjectConfig) Parse(defaultBranches []*BranchConfig, releaseChannels *semver.ReleaseChannels) error {
+	if c.Name == "" {
+		return fmt.Errorf("missing project name")
+	}
+
+	if path.IsAbs(c.Path) || strings.HasPrefix(c.GetPath(), "..") {
+		return fmt.Errorf("invalid path \"%s\" of project %s: must be relative to the repository root", c.Path, c.Name)
+	}
+
+	if c.DependencyIncrement == "" {
+		c.DependencyIncrement = DependencyIncrementPatch
+	}
+
+	if c.DependencyIncrement != DependencyIncrementPatch &&
+		c.DependencyIncrement != DependencyIncrementMinor &&
+		c.DependencyIncrement != DependencyIncrementSame {
+		return fmt.Errorf("invalid dependency increment of project %s: %s", c.Name, c.DependencyIncrement)
+	}
+
+	for _, bumpFile := range c.BumpFiles {
+		err := bumpFile.Parse()
+		if err != nil {
+			return fmt.Errorf("invalid bump file config of project %s: %s", c.Name, err)
+		}
+	}
+
+	branches := c.Branches
+	if len(branches) == 0 {
+		branches = defaultBranches
+	}
+
+	c.branches = []*BranchConfig{}
+	foundFinalReleaseChannel := false
+
+	for _, branch := range branches {
+		branchConfig := branch.withTagPrefix(c.TagPrefix)
+
+		err := branchConfig.Parse(releaseChannels)
+		if err != nil {
+			return fmt.Errorf("invalid branch config of project %s: %s", c.Name, err)
+		}
+
+		if branchConfig.ReleaseChannel == semver.ReleaseChannelFinal {
+			foundFinalReleaseChannel = true
+		}
+
+		c.branches = append(c.branches, branchConfig)
+	}
+
+	if !foundFinalReleaseChannel {
+		return fmt.Errorf("no branch with release-channel 'FINAL' configured for project %s", c.Name)
+	}
+
+	return nil
+}
+
+// VersionStrategy specifies how the base version is selected
+type VersionStrategy string
+
+const (
+	VersionStrategyLatest        VersionStrategy = "LATEST"
+	VersionStrategyOverallLatest VersionStrategy = "OVERALL_LATEST"
+	VersionStrategyClosest       VersionStrategy = "CLOSEST"
+)
+
+// SignMethod specifies how tags are signed
+type SignMethod string
+
+const (
+	SignMethodNone SignMethod = ""
+	SignMethodGPG  SignMethod = "GPG"
+	SignMethodSSH  SignMethod = "SSH"
+)
+
+// TagConfig is the configuration of the tag command
+type TagConfig struct {
+	// Lightweight creates lightweight tags instead of annotated tags
+	Lightweight bool `yaml:"lightweight,omitempty"`
+	// TaggerName and TaggerEmail are the identity of annotated tags, defaults
+	// to user.name and user.email from the git config
+	TaggerName  string `yaml:"tagger_name,omitempty"`
+	TaggerEmail string `yaml:"tagger_email,omitempty"`
+	// Sign specifies the method used to sign annotated tags
+	Sign SignMethod `yaml:"sign,omitempty"`
+	// SigningKey is the path to the armored GPG private key or the SSH
+	// private key used for signing
+	SigningKey string `yaml:"signing_key,omitempty"`
+	// Remote is the name of the remote the tag is pushed to, tags are not
+	// pushed if empty
+	Remote string `yaml:"remote,omitempty"`
+	// MaxRetries is the maximum number of retries if the tag already exists
+	// on the remote (default 3)
+	MaxRetries int `yaml:"max_retries,omitempty"`
+}
+
+// DefaultTagMaxRetries is used if MaxRetries is not set
+const DefaultTagMaxRetries = 3
+
+// Parse validates the tag config
+func (c *TagConfig) Parse() error {
+	if c.Sign != SignMethodNone &&
+		c.Sign != SignMethodGPG &&
+		c.Sign != SignMethodSSH {
+		return fmt.Errorf("invalid sign method \"%s\"", c.Sign)
+	}
+
+	if c.Sign != SignMethodNone && c.Lightweight {
+		return fmt.Errorf("lightweight tags can't be signed")
+	}
+
+	if c.Sign != SignMethodNone && c.SigningKey == "" {
+		return fmt.Errorf("missing signing key")
+	}
+
+	if c.MaxRetries < 0 {
+		return fmt.Errorf("invalid max retries %d", c.MaxRetries)
+	}
+
+	if c.MaxRetries == 0 {
+		c.MaxRetries = DefaultTagMaxRetries
+	}
+
+	return nil
+}
+
+// DefaultTagConfig is used if no tag config is set
+var DefaultTagConfig = &TagConfig{
+	MaxRetries: DefaultTagMaxRetries,
+}
+
+// ChangelogConfig is the configuration of the changelog file
+type ChangelogConfig struct {
+	// File is the path of the changelog file (default CHANGELOG.md)
+	File string `yaml:"file,omitempty"`
+	// CompareURL is the url comparing two versions used to link the version
+	// sections (placeholders: {previous}, {version})
+	CompareURL string `yaml:"compare_url,omitempty"`
+	// Template is the name of a built-in changelog template, the path of a
+	// template file or an inline template (go text/template)
+	Template string `yaml:"template,omitempty"`
+	// Mailmap is the path of the mailmap file normalizing author names and
+	// emails (default .mailmap, ignored if missing)
+	Mailmap string `yaml:"mailmap,omitempty"`
+	// Contributors adds a section listing all contributors of the changes
+	Contributors bool `yaml:"contributors,omitempty"`
+	// GroupByScope groups the changes of each section by scope
+	GroupByScope bool `yaml:"group_by_scope,omitempty"`
+	// Scopes defines aliases, ordering and components of scopes
+	Scopes []*changelog.Scope `yaml:"scopes,omitempty"`
+	// HiddenScopes are scopes whose changes are omitted from changelogs (e.g. 'ci')
+	HiddenScopes []string `yaml:"hidden_scopes,omitempty"`
+}
+
+// Parse validates the changelog config
+func (c *ChangelogConfig) Parse() error {
+	if c.File == "" {
+		c.File = DefaultChangelogFile
+	}
+
+	scopeNames := map[string]bool{}
+
+	for _, scope := range c.Scopes {
+		err := scope.Parse()
+		if err != nil {
+			return err
+		}
+
+		if scopeNames[scope.Name] {
+			return fmt.Errorf("duplicate scope %s", scope.Name)
+		}
+
+		scopeNames[scope.Name] = true
+	}
+
+	return nil
+}
+
+// DefaultChangelogFile is used if no changelog file is configured
+const DefaultChangelogFile = "CHANGELOG.md"
+
+// DefaultMailmapFile is used if no mailmap file is configured
+const DefaultMailmapFile = ".mailmap"
+
+// DefaultChangelogConfig is used if no changelog config is set
+var DefaultChangelogConfig = &ChangelogConfig{
+	File: DefaultChangelogFile,
+}
+
+// PublishProvider specifies the hosting service releases are published to
+type PublishProvider string
+
+const (
+	PublishProviderGitHub PublishProvider = "GITHUB"
+	PublishProviderGitLab PublishProvider = "GITLAB"
+	PublishProviderGitea  PublishProvider = "GITEA"
+)
+
+// DefaultGitHubURL is the API url used for GITHUB if no url is configured
+const DefaultGitHubURL = "https://api.github.com"
+
+// DefaultGitLabURL is the API url used for GITLAB if no url is configured
+const DefaultGitLabURL = "https://gitlab.com/api/v4"
+
+// PublishConfig is the configuration of the publish-release command
+type PublishConfig struct {
+	// Provider is the hosting service (GITHUB, GITLAB, GITEA or a registered
+	// custom provider)
+	Provider PublishProvider `yaml:"provider"`
+	// URL is the base url of the provider's REST API (e.g.
+	// 'https://gitea.example.com/api/v1'), required for GITEA
+	URL string `yaml:"url,omitempty"`
+	// Repository is the path of the repository on the hosting service (e.g.
+	// 'org/repo')
+	Repository string `yaml:"repository"`
+	// Assets contains the paths of the files uploaded to the release (glob
+	// patterns like 'dist/*.tar.gz')
+	Assets []string `yaml:"assets,omitempty"`
+}
+
+// Parse validates the publish config, providers without built-in support
+// are accepted for custom providers
+func (c *PublishConfig) Parse() error {
+	if c.Provider == "" {
+		return fmt.Errorf("missing provider")
+	}
+
+	if c.Repository == "" {
+		return fmt.Errorf("missing repository")
+	}
+
+	if c.URL == "" {
+		switch c.Provider {
+		case PublishProviderGitHub:
+			c.URL = DefaultGitHubURL
+		case PublishProviderGitLab:
+			c.URL = DefaultGitLabURL
+		default:
+			return fmt.Errorf("missing url of provider %s", c.Provider)
+		}
+	}
+
+	c.URL = strings.TrimSuffix(c.URL, "/")
+
+	return nil
+}
+
+// Config is the root configuration
+type Config struct {
+	Branches    []*BranchConfig         `yaml:"branches"`
+	Strategy    VersionStrategy         `yaml:"strategy"`
+	CommitTypes []*changelog.CommitType `yaml:"commit_types,omitempty"`
+	// IssuePatterns detect issue references in commit messages (default:
+	// '#123' without links)
+	IssuePatterns []*changelog.IssuePattern `yaml:"issue_patterns,omitempty"`
+	Tag           *TagConfig                `yaml:"tag,omitempty"`
+	Changelog     *ChangelogConfig          `yaml:"changelog,omitempty"`
+	Projects      []*ProjectConfig          `yaml:"projects,omitempty"`
+	BumpFiles     []*BumpFileConfig         `yaml:"bump_files,omitempty"`
+	// Publish is the configuration of the publish-release command, releases
+	// can't be published if nil
+	Publish *PublishConfig `yaml:"publish,omitempty"`
+	// ReleaseChannels defines the release channels and their ordering, replaces
+	// the default release channels ALPHA, BETA, GAMMA and FINAL
+	ReleaseChannels []*semver.ReleaseChannelDefinition `yaml:"release_channels,omitempty"`
+
+	projectOrder    []*ProjectConfig
+	releaseChannels *semver.ReleaseChannels
+}
+
+// GetReleaseChannels returns the defined release channels (the configured or
+// the default release channels)
+func (c *Config) GetReleaseChannels() *semver.ReleaseChannels {
+	return c.releaseChannels
+}
+
+// GetProjectOrder returns all projects ordered topologically by their
+// dependencies (dependencies first)
+func (c *Config) GetProjectOrder() []*ProjectConfig {
+	return c.projectOrder
+}
+
+// sortProjects orders the projects topologically by their dependencies,
+// returns an error if a dependency is unknown or cyclic
+func (c *Config) sortProjects() ([]*ProjectConfig, error) {
+	const (
+		stateVisiting = 1
+		stateDone     = 2
+	)
+
+	order := []*ProjectConfig{}
+	states := map[string]int{}
+
+	var visit func(project *ProjectConfig, chain []string) error
+	visit = func(project *ProjectConfig, chain []string) error {
+		chain = append(chain, project.Name)
+
+		switch states[project.Name] {
+		case stateVisiting:
+			return fmt.Errorf("cyclic project dependency %s", strings.Join(chain, " -> "))
+		case stateDone:
+			return nil
+		}
+
+		states[project.Name] = stateVisiting
+
+		for _, dependency := range project.DependsOn {
+			dependencyProject := c.GetProject(dependency)
+			if dependencyProject == nil {
+				return fmt.Errorf("unknown dependency %s of project %s", dependency, project.Name)
+			}
+
+			err := visit(dependencyProject, chain)
+			if err != nil {
+				return err
+			}
+		}
+
+		states[project.Name] = stateDone
+		order = append(order, project)
+
+		return nil
+	}
+
+	for _, project := range c.Projects {
+		err := visit(project, []string{})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return order, nil
+}
+
+// GetProject returns the config of a project, nil if it doesn't exist
+func (c *Config) GetProject(name string) *ProjectConfig {
+	for _, project := range c.Projects {
+		if project.Name == name {
+			return project
+		}
+	}
+
+	return nil
+}
+
+// GetChangelogFile returns the path of the changelog file of a project (located
+// in the project's path) or of the root project if project is nil
+func (c *Config) GetChangelogFile(project *ProjectConfig) string {
+	if project == nil || path.IsAbs(c.Changelog.File) {
+		return c.Changelog.File
+	}
+
+	return path.Join(project.GetPath(), c.Changelog.File)
+}
+
+// GetBumpFiles returns the files the version of a project is written to and
+// the directory their paths are relative to
+func (c *Config) GetBumpFiles(project *ProjectConfig) ([]*BumpFileConfig, string) {
+	if project == nil {
+		return c.BumpFiles, ""
+	}
+
+	return project.BumpFiles, project.GetPath()
+}
+
+// parseReleaseChannels validates the configured release channels and defines
+// them (or the default release channels if none are configured)
+func (c *Config) parseReleaseChannels() error {
+	if len(c.ReleaseChannels) == 0 {
+		c.releaseChannels = semver.NewReleaseChannels(semver.DefaultReleaseChannels)
+
+		return nil
+	}
+
+	names := map[semver.ReleaseChannel]bool{}
+	foundFinal := false
+
+	for _, releaseChannel := range c.ReleaseChannels {
+		if releaseChannel.Name == semver.ReleaseChannelNone {
+			return fmt.Errorf("missing name of release channel")
+		}
+
+		if names[releaseChannel.Name] {
+			return fmt.Errorf("duplicate release channel %s", releaseChannel.Name)
+		}
+
+		if releaseChannel.Priority <= 0 {
+			return fmt.Errorf("invalid priority %d of release channel %s", releaseChannel.Priority, releaseChannel.Name)
+		}
+
+		if releaseChannel.Name == semver.ReleaseChannelFinal {
+			if !releaseChannel.IsRelease() {
+				return fmt.Errorf("release channel FINAL must be a release")
+			}
+
+			foundFinal = true
+		}
+
+		names[releaseChannel.Name] = true
+	}
+
+	if !foundFinal {
+		return fmt.Errorf("release channel FINAL must be defined")
+	}
+
+	c.releaseChannels = semver.NewReleaseChannels(c.ReleaseChannels)
+
+	return nil
+}
+
+// Parse validates the config and parses all branch configs and commit types
+func (c *Config) Parse() error {
+	if c.Strategy != VersionStrategyLatest &&
+		c.Strategy != VersionStrategyOverallLatest &&
+		c.Strategy != VersionStrategyClosest {
+		return fmt.Errorf("invalid strategy \"%s\"", c.Strategy)
+	}
+
+	err := c.parseReleaseChannels()
+	if err != nil {
+		return fmt.Errorf("invalid release channels: %s", err)
+	}
+
+	foundFinalReleaseChannel := false
+
+	for _, branch := range c.Branches {
+		err := branch.Parse(c.releaseChannels)
+		if err != nil {
+			return err
+		}
+
+		if branch.ReleaseChannel == semver.ReleaseChannelFinal {
+			foundFinalReleaseChannel = true
+		}
+	}
+
+	if !foundFinalReleaseChannel {
+		return fmt.Errorf("no branch with release-channel 'FINAL' configured")
+	}
+
+	if len(c.CommitTypes) == 0 {
+		c.CommitTypes = copyCommitTypes(changelog.DefaultCommitTypes)
+	}
+
+	for _, commitType := range c.CommitTypes {
+		err := commitType.Parse()
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(c.IssuePatterns) == 0 {
+		c.IssuePatterns = copyIssuePatterns(changelog.DefaultIssuePatterns)
+	}
+
+	for _, issuePattern := range c.IssuePatterns {
+		err := issuePattern.Parse()
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.Tag == nil {
+		c.Tag = copyTagConfig(DefaultTagConfig)
+	}
+
+	err = c.Tag.Parse()
+	if err != nil {
+		return fmt.Errorf("invalid tag config: %s", err)
+	}
+
+	if c.Changelog == nil {
+		c.Changelog = copyChangelogConfig(DefaultChangelogConfig)
+	}
+
+	err = c.Changelog.Parse()
+	if err != nil {
+		return fmt.Errorf("invalid changelog config: %s", err)
+	}
+
+	for _, bumpFile := range c.BumpFiles {
+		err = bumpFile.Parse()
+		if err != nil {
+			return fmt.Errorf("invalid bump file config: %s", err)
+		}
+	}
+
+	if c.Publish != nil {
+		err = c.Publish.Parse()
+		if err != nil {
+			return fmt.Errorf("invalid publish config: %s", err)
+		}
+	}
+
+	projectNames := map[string]bool{}
+
+	for _, project := range c.Projects {
+		err = project.Parse(c.Branches, c.releaseChannels)
+		if err != nil {
+			return err
+		}
+
+		if projectNames[project.Name] {
+			return fmt.Errorf("duplicate project %s", project.Name)
+		}
+
+		projectNames[project.Name] = true
+	}
+
+	c.projectOrder, err = c.sortProjects()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// copyCommitTypes copies the commit types, so parsing the copies doesn't modify
+// shared commit types (e.g. changelog.DefaultCommitTypes)
+func copyCommitTypes(commitTypes []*changelog.CommitType) []*changelog.CommitType {
+	copies := make([]*changelog.CommitType, 0, len(commitTypes))
+
+	for _, commitType := range commitTypes {
+		commitTypeCopy := *commitType
+		copies = append(copies, &commitTypeCopy)
+	}
+
+	return copies
+}
+
+// copyIssuePatterns copies the issue patterns, so parsing the copies doesn't
+// modify shared issue patterns (e.g. changelog.DefaultIssuePatterns)
+func copyIssuePatterns(issuePatterns []*changelog.IssuePattern) []*changelog.IssuePattern {
+	copies := make([]*changelog.IssuePattern, 0, len(issuePatterns))
+
+	for _, issuePattern := range issuePatterns {
+		issuePatternCopy := *issuePattern
+		copies = append(copies, &issuePatternCopy)
+	}
+
+	return copies
+}
+
+// copyTagConfig copies the tag config, so parsing the copy doesn't modify a
+// shared tag config (e.g. DefaultTagConfig)
+func copyTagConfig(tagConfig *TagConfig) *TagConfig {
+	tagConfigCopy := *tagConfig
+
+	return &tagConfigCopy
+}
+
+// copyChangelogConfig copies the changelog config, so parsing the copy doesn't
+// modify a shared changelog config (e.g. DefaultChangelogConfig)
+func copyChangelogConfig(changelogConfig *ChangelogConfig) *ChangelogConfig {
+	changelogConfigCopy := *changelogConfig
+
+	return &changelogConfigCopy
+}
+
+// DefaultConfig is used if no config file exists
+var DefaultConfig = &Config{
+	Strategy:      VersionStrategyLatest,
+	CommitTypes:   copyCommitTypes(changelog.DefaultCommitTypes),
+	IssuePatterns: copyIssuePatterns(changelog.DefaultIssuePatterns),
+	Tag:           copyTagConfig(DefaultTagConfig),
+	Changelog:     copyChangelogConfig(DefaultChangelogConfig),
+	Branches: []*BranchConfig{
+		{
+			BranchPattern:  "master",
+			VersionPattern: "v{major}.{minor}.{patch}",
+			ReleaseChannel: semver.ReleaseChannelFinal,
+		},
+		{
+			BranchPattern:  "release.*",
+			VersionPattern: "v{major}.{minor}.{patch}",
+			ReleaseChannel: semver.ReleaseChannelFinal,
+		},
+		{
+			BranchPattern:  "gamma.*",
+			VersionPattern: "v{major}.{minor}.{patch}-gamma.{build}",
+			ReleaseChannel: semver.ReleaseChannelGamma,
+		},
+		{
+			BranchPattern:  "beta.*",
+			VersionPattern: "v{major}.{minor}.{patch}-beta.{build}",
+			ReleaseChannel: semver.ReleaseChannelBeta,
+		},
+		{
+			BranchPattern:  "alpha.*",
+			VersionPattern: "v{major}.{minor}.{patch}-alpha.{build}",
+			ReleaseChannel: semver.ReleaseChannelAlpha,
+		},
+		{
+			BranchPattern:  "feat.*",
+			VersionPattern: "v{major}.{minor}.{patch}-{branch}.{build}",
+		},
+		{
+			BranchPattern:  "fix.*",
+			VersionPattern: "v{major}.{minor}.{patch}-{branch}.{build}",
+		},
+	},
+}
+
+// Generate writes the default config to a new file
+func Generate(filename string) error {
+	_, err := os.Stat(filename)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("can't access file %s: %s", filename, err)
+	}
+
+	if err == nil {
+		return fmt.Errorf("file %s already exists: %s", filename, err)
+	}
+
+	configData, err := yaml.Marshal(DefaultConfig)
+	if err != nil {
+		return fmt.Errorf("can't encode default config: %s", err)
+	}
+
+	err = ioutil.WriteFile(filename, configData, 0)
+	if err != nil {
+		return fmt.Errorf("can't write default config to file %s: %s", filename, err)
+	}
+
+	return nil
+}
+
+// Load loads and parses the config file, the default config is returned
+// if the file doesn't exist
+func Load(filename string) (*Config, error) {
+	return LoadWithLogger(filename, nil)
+}
+
+// LoadWithLogger loads and parses the config file like Load, diagnostics are
+// written to logger (the default logger if nil)
+func LoadWithLogger(filename string, logger semver.Logger) (*Config, error) {
+	logger = semver.GetLogger(logger)
+
+	_, err := os.Stat(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			logger.Infof("Config file %s not found, using default config", filename)
+
+			err = DefaultConfig.Parse()
+			if err != nil {
+				return nil, err
+			}
+
+			return DefaultConfig, nil
+		}
+
+		return nil, fmt.Errorf("can't open config file %s: %s", filename, err)
+	}
+
+	logger.Debugf("Loading config file %s", filename)
+
+	config := &Config{}
+
+	configData, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("can't read config file %s: %s", filename, err)
+	}
+
+	err = yaml.Unmarshal(configData, config)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse config file %s: %s", filename, err)
+	}
+
+	err = config.Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Debugf("Loaded config file %s with %d branch configs and %d projects", filename, len(config.Branches), len(config.Projects))
+
+	return config, nil
+}
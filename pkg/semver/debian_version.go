@@ -0,0 +1,183 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var expDebianUpstreamVersion = regexp.MustCompile(`^[0-9][A-Za-z0-9\.\+~\-]*$`)
+var expDebianRevision = regexp.MustCompile(`^[A-Za-z0-9\.\+~]+$`)
+
+// DebianVersion is a Debian package version ('[epoch:]upstream[-revision]')
+type DebianVersion struct {
+	str      string
+	Epoch    int
+	Upstream string
+	Revision string
+}
+
+// String returns the version as parsed
+func (v *DebianVersion) String() string {
+	return v.str
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// getDebianOrder returns the sort weight of the character at position i of
+// a non-digit part: '~' sorts before the end of the part, letters before all
+// other characters
+func getDebianOrder(str string, i int) int {
+	if i >= len(str) {
+		return 0
+	}
+
+	c := str[i]
+
+	switch {
+	case isDigit(c):
+		return 0
+	case (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+		return int(c)
+	case c == '~':
+		return -1
+	default:
+		return int(c) + 256
+	}
+}
+
+// compareDebianParts compares upstream versions or revisions like dpkg's
+// verrevcmp: alternating non-digit parts (compared by getDebianOrder) and
+// digit parts (compared numerically)
+func compareDebianParts(a string, b string) int {
+	i := 0
+	j := 0
+
+	for i < len(a) || j < len(b) {
+		for (i < len(a) && !isDigit(a[i])) || (j < len(b) && !isDigit(b[j])) {
+			orderA := getDebianOrder(a, i)
+			orderB := getDebianOrder(b, j)
+			if orderA != orderB {
+				return compareInts(orderA, orderB)
+			}
+
+			i++
+			j++
+		}
+
+		for i < len(a) && a[i] == '0' {
+			i++
+		}
+
+		for j < len(b) && b[j] == '0' {
+			j++
+		}
+
+		firstDiff := 0
+		for i < len(a) && isDigit(a[i]) && j < len(b) && isDigit(b[j]) {
+			if firstDiff == 0 {
+				firstDiff = compareInts(int(a[i]), int(b[j]))
+			}
+
+			i++
+			j++
+		}
+
+		if i < len(a) && isDigit(a[i]) {
+			return 1
+		}
+
+		if j < len(b) && isDigit(b[j]) {
+			return -1
+		}
+
+		if firstDiff != 0 {
+			return firstDiff
+		}
+	}
+
+	return 0
+}
+
+// Compare compares the versions like dpkg (e.g. 1.0~rc1 < 1.0 < 1.0-1 < 1.0+dfsg)
+func (v *DebianVersion) Compare(b *DebianVersion) int {
+	if c := compareInts(v.Epoch, b.Epoch); c != 0 {
+		return c
+	}
+
+	if c := compareDebianParts(v.Upstream, b.Upstream); c != 0 {
+		return c
+	}
+
+	return compareDebianParts(v.Revision, b.Revision)
+}
+
+// ParseDebianVersion parses a Debian package version
+func ParseDebianVersion(str string) (*DebianVersion, error) {
+	version := &DebianVersion{
+		str: str,
+	}
+
+	upstream := str
+
+	if index := strings.Index(upstream, ":"); index >= 0 {
+		epoch, err := strconv.Atoi(upstream[:index])
+		if err != nil {
+			return nil, fmt.Errorf("invalid epoch in \"%s\": %s", str, err)
+		}
+
+		version.Epoch = epoch
+		upstream = upstream[index+1:]
+	}
+
+	if index := strings.LastIndex(upstream, "-"); index >= 0 {
+		version.Revision = upstream[index+1:]
+		upstream = upstream[:index]
+
+		if !expDebianRevision.MatchString(version.Revision) {
+			return nil, fmt.Errorf("invalid revision in \"%s\"", str)
+		}
+	}
+
+	if !expDebianUpstreamVersion.MatchString(upstream) {
+		return nil, fmt.Errorf("invalid upstream version in \"%s\"", str)
+	}
+
+	version.Upstream = upstream
+
+	return version, nil
+}
+
+type debianScheme struct{}
+
+func (s *debianScheme) GetName() string {
+	return VersionSchemeDebian
+}
+
+func (s *debianScheme) Parse(version string) (SchemeVersion, error) {
+	parsedVersion, err := ParseDebianVersion(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsedVersion, nil
+}
+
+func (s *debianScheme) Compare(a SchemeVersion, b SchemeVersion) int {
+	return a.(*DebianVersion).Compare(b.(*DebianVersion))
+}
+
+func (s *debianScheme) GetQualifier(releaseChannel ReleaseChannel, releaseChannels *ReleaseChannels) string {
+	return getQualifier(releaseChannel, releaseChannels, "~", "~dev")
+}
+
+func (s *debianScheme) GetQualifierPattern() string {
+	return `(?:~[a-zA-Z]+)?`
+}
+
+func (s *debianScheme) ParseQualifier(qualifier string, releaseChannels *ReleaseChannels) ReleaseChannel {
+	return parseQualifierName(getQualifierName(qualifier), releaseChannels)
+}
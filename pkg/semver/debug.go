@@ -0,0 +1,6 @@
+package semver
+
+// Debugf writes a debug message to the default logger
+func Debugf(msg string, args ...interface{}) {
+	GetDefaultLogger().Debugf(msg, args...)
+}
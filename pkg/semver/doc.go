@@ -0,0 +1,39 @@
+// Package semver generates semantic versions and changelogs from the history
+// of a git repository.
+//
+// The package contains the basic version model (VersionInfo, ReleaseChannel,
+// VersionIncrement), semantic versions following semver 2.0.0 (Version) and
+// version schemes for other ecosystems (VersionScheme, e.g. Maven, PEP 440)
+// and the Logger receiving diagnostics, the subpackages provide the remaining
+// functionality:
+//
+//	pattern    Branch and version patterns (parsing and generating tags)
+//	changelog  Commit message parsing and changelog generation
+//	config     Configuration file handling (semanticversion.yaml)
+//	analyzer   Analysis of a git repository
+//	release    Creation, signing and pushing of release tags
+//	bump       Updating the version in project files
+//	publish    Publishing releases on GitHub, GitLab and Gitea
+//	graph      Rendering the history with version tags (ASCII, DOT, Mermaid)
+//
+// Example:
+//
+//	cfg, err := config.Load("./semanticversion.yaml")
+//	if err != nil {
+//		return err
+//	}
+//
+//	repo, err := git.PlainOpen(".")
+//	if err != nil {
+//		return err
+//	}
+//
+//	a := analyzer.NewAnalyzer(repo, cfg, &analyzer.Options{})
+//
+//	err = a.Load()
+//	if err != nil {
+//		return err
+//	}
+//
+//	version, err := a.GetVersion()
+package semver
@@ -0,0 +1,146 @@
+package graph
+
+import (
+	"strings"
+)
+
+// laneMove is the move of a lane from one column to another
+type laneMove struct {
+	from int
+	to   int
+}
+
+// renderMoves draws the rows moving the lanes to their new columns, each row
+// moves a lane by at most one column
+func renderMoves(moves []laneMove) []string {
+	rows := []string{}
+
+	for {
+		width := 0
+		for _, move := range moves {
+			if 2*move.from+2 > width {
+				width = 2*move.from + 2
+			}
+		}
+
+		row := []byte(strings.Repeat(" ", width))
+		moved := false
+
+		for i, move := range moves {
+			switch {
+			case move.to < move.from:
+				row[2*move.from-1] = '/'
+				moves[i].from--
+				moved = true
+			case move.to > move.from:
+				row[2*move.from+1] = '\\'
+				moves[i].from++
+				moved = true
+			default:
+				row[2*move.from] = '|'
+			}
+		}
+
+		if !moved {
+			return rows
+		}
+
+		rows = append(rows, strings.TrimRight(string(row), " "))
+	}
+}
+
+// renderLanes draws a row with a '|' for each lane and the commit marker '*'
+// in column commitColumn
+func renderLanes(count int, commitColumn int) string {
+	columns := []string{}
+
+	for i := 0; i < count; i++ {
+		if i == commitColumn {
+			columns = append(columns, "*")
+		} else {
+			columns = append(columns, "|")
+		}
+	}
+
+	return strings.Join(columns, " ")
+}
+
+// RenderASCII renders the graph similar to 'git log --graph' with the tags,
+// their parsed versions and the versions computed for HEAD
+func (g *Graph) RenderASCII() string {
+	// lanes contains the hash of the commit expected in each column
+	lanes := []string{}
+	str := ""
+
+	for _, commit := range g.Commits {
+		columns := []int{}
+		for i, hash := range lanes {
+			if hash == commit.Hash {
+				columns = append(columns, i)
+			}
+		}
+
+		if len(columns) == 0 {
+			lanes = append(lanes, commit.Hash)
+			columns = append(columns, len(lanes)-1)
+		}
+
+		column := columns[0]
+
+		// Merge all lanes leading to the commit
+		if len(columns) > 1 {
+			moves := []laneMove{}
+			newLanes := []string{}
+
+			for i, hash := range lanes {
+				if hash == commit.Hash && i != column {
+					moves = append(moves, laneMove{from: i, to: column})
+
+					continue
+				}
+
+				moves = append(moves, laneMove{from: i, to: len(newLanes)})
+				newLanes = append(newLanes, hash)
+			}
+
+			for _, row := range renderMoves(moves) {
+				str += row + "\n"
+			}
+
+			lanes = newLanes
+		}
+
+		row := renderLanes(len(lanes), column) + "  " + commit.ShortHash()
+		for _, label := range g.getLabels(commit) {
+			row += " " + label
+		}
+
+		str += row + " " + commit.Subject + "\n"
+
+		// Replace the commit's lane by its parents
+		moves := []laneMove{}
+		newLanes := []string{}
+
+		for i, hash := range lanes {
+			if i != column {
+				moves = append(moves, laneMove{from: i, to: len(newLanes)})
+				newLanes = append(newLanes, hash)
+
+				continue
+			}
+
+			for _, parent := range commit.Parents {
+				moves = append(moves, laneMove{from: i, to: len(newLanes)})
+				newLanes = append(newLanes, parent)
+			}
+		}
+
+		for _, row := range renderMoves(moves) {
+			str += row + "\n"
+		}
+
+		lanes = newLanes
+	}
+
+	return str
+}
@@ -0,0 +1,56 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+)
+
+// escapeDOT escapes a string for a quoted Graphviz DOT id
+func escapeDOT(str string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(str)
+}
+
+// RenderDOT renders the graph as Graphviz DOT (e.g. for 'dot -Tsvg')
+//
+// Commits with version tags are filled and base tags of a strategy are bold.
+func (g *Graph) RenderDOT() string {
+	str := "digraph history {\n"
+	str += "  rankdir=BT;\n"
+	str += "  node [shape=box, fontname=\"monospace\"];\n"
+
+	for _, commit := range g.Commits {
+		lines := []string{commit.ShortHash() + " " + commit.Subject}
+		lines = append(lines, g.getLabels(commit)...)
+
+		labels := []string{}
+		for _, line := range lines {
+			labels = append(labels, escapeDOT(line))
+		}
+
+		attributes := fmt.Sprintf("label=\"%s\\l\"", strings.Join(labels, "\\l"))
+
+		if len(commit.Tags) > 0 {
+			attributes += ", style=filled, fillcolor=\"lightblue\""
+		}
+
+		for _, tag := range commit.Tags {
+			if len(g.getBaseStrategies(tag)) > 0 {
+				attributes += ", penwidth=3"
+
+				break
+			}
+		}
+
+		str += fmt.Sprintf("  \"%s\" [%s];\n", commit.Hash, attributes)
+	}
+
+	for _, commit := range g.Commits {
+		for _, parent := range commit.Parents {
+			str += fmt.Sprintf("  \"%s\" -> \"%s\";\n", parent, commit.Hash)
+		}
+	}
+
+	str += "}\n"
+
+	return str
+}
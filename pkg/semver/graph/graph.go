@@ -0,0 +1,121 @@
+// Package graph renders the history of a repository with its version tags
+// as ASCII, Graphviz DOT or Mermaid graph.
+package graph
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/indece-official/semantic-version/pkg/semver"
+)
+
+// Commit is a commit of the history
+type Commit struct {
+	Hash string
+	// Parents contains the hashes of the parents contained in the graph
+	Parents []string
+	// Subject is the first line of the commit message
+	Subject string
+	// Branches contains the names of all local branches pointing to the commit
+	Branches []string
+	// Tags contains all version tags of the commit
+	Tags   []*semver.Tag
+	IsHead bool
+}
+
+// shortHash returns the abbreviated commit hash
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+
+	return hash
+}
+
+// ShortHash returns the abbreviated commit hash
+func (c *Commit) ShortHash() string {
+	return shortHash(c.Hash)
+}
+
+// Strategy is the version of HEAD computed with a strategy
+type Strategy struct {
+	Name string
+	// BaseTag is the final release tag the version is based on, nil if there
+	// is no final release
+	BaseTag *semver.Tag
+	Version string
+}
+
+// Graph is the history of a repository with its version tags
+type Graph struct {
+	// Commits contains all commits, children before their parents
+	Commits []*Commit
+	// Strategies contains the version of HEAD computed with each strategy,
+	// empty if no branch config matches HEAD
+	Strategies []*Strategy
+}
+
+// formatVersionInfo formats the components of a parsed version (e.g.
+// '1.2.0.3 BETA')
+func formatVersionInfo(versionInfo *semver.VersionInfo) string {
+	str := fmt.Sprintf("%d.%d.%d", versionInfo.Major, versionInfo.Minor, versionInfo.Patch)
+	if versionInfo.Build != 0 {
+		str += fmt.Sprintf(".%d", versionInfo.Build)
+	}
+
+	if versionInfo.ReleaseChannel == semver.ReleaseChannelNone {
+		return str
+	}
+
+	return str + " " + string(versionInfo.ReleaseChannel)
+}
+
+// getBaseStrategies returns the names of all strategies using the tag as base
+func (g *Graph) getBaseStrategies(tag *semver.Tag) []string {
+	names := []string{}
+
+	for _, strategy := range g.Strategies {
+		if strategy.BaseTag != nil && strategy.BaseTag.Name == tag.Name {
+			names = append(names, strategy.Name)
+		}
+	}
+
+	return names
+}
+
+// getLabels returns the annotations of a commit: refs, tags with their
+// parsed versions and the versions computed for HEAD
+func (g *Graph) getLabels(commit *Commit) []string {
+	labels := []string{}
+
+	refs := []string{}
+	if commit.IsHead {
+		refs = append(refs, "HEAD")
+	}
+	refs = append(refs, commit.Branches...)
+
+	if len(refs) > 0 {
+		labels = append(labels, fmt.Sprintf("(%s)", strings.Join(refs, ", ")))
+	}
+
+	for _, tag := range commit.Tags {
+		label := fmt.Sprintf("[%s: %s]", tag.Name, formatVersionInfo(tag.Version))
+
+		if strategies := g.getBaseStrategies(tag); len(strategies) > 0 {
+			label += fmt.Sprintf(" <- base of %s", strings.Join(strategies, ", "))
+		}
+
+		labels = append(labels, label)
+	}
+
+	if commit.IsHead && len(g.Strategies) > 0 {
+		versions := []string{}
+		for _, strategy := range g.Strategies {
+			versions = append(versions, fmt.Sprintf("%s: %s", strategy.Name, strategy.Version))
+		}
+
+		labels = append(labels, fmt.Sprintf("=> %s", strings.Join(versions, ", ")))
+	}
+
+	return labels
+}
@@ -0,0 +1,61 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/indece-official/semantic-version/pkg/semver"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestGraph() *Graph {
+	baseTag := semver.NewTag("v1.0.0", &semver.VersionInfo{Major: 1, ReleaseChannel: semver.ReleaseChannelFinal}, "1000000")
+	betaTag := semver.NewTag("v1.1.0-beta.2", &semver.VersionInfo{Major: 1, Minor: 1, Build: 2, ReleaseChannel: semver.ReleaseChannelBeta}, "3000000")
+
+	return &Graph{
+		Commits: []*Commit{
+			{Hash: "5000000", Parents: []string{"4000000", "3000000"}, Subject: "Merge feat/b", Branches: []string{"develop"}, IsHead: true},
+			{Hash: "4000000", Parents: []string{"2000000"}, Subject: "fix: c"},
+			{Hash: "3000000", Parents: []string{"2000000"}, Subject: "feat: \"b\"", Branches: []string{"feat/b"}, Tags: []*semver.Tag{betaTag}},
+			{Hash: "2000000", Parents: []string{"1000000"}, Subject: "feat: a"},
+			{Hash: "1000000", Parents: []string{}, Subject: "init", Tags: []*semver.Tag{baseTag}},
+		},
+		Strategies: []*Strategy{
+			{Name: "LATEST", BaseTag: baseTag, Version: "v1.1.0-develop.0"},
+			{Name: "CLOSEST", BaseTag: baseTag, Version: "v1.1.0-develop.0"},
+		},
+	}
+}
+
+func TestGraphRenderASCII(t *testing.T) {
+	history := newTestGraph()
+
+	assert.Equal(t, ""+
+		"*  5000000 (HEAD, develop) => LATEST: v1.1.0-develop.0, CLOSEST: v1.1.0-develop.0 Merge feat/b\n"+
+		"|\\\n"+
+		"* |  4000000 fix: c\n"+
+		"| *  3000000 (feat/b) [v1.1.0-beta.2: 1.1.0.2 BETA] feat: \"b\"\n"+
+		"|/\n"+
+		"*  2000000 feat: a\n"+
+		"*  1000000 [v1.0.0: 1.0.0 FINAL] <- base of LATEST, CLOSEST init\n",
+		history.RenderASCII())
+}
+
+func TestGraphRenderDOT(t *testing.T) {
+	history := newTestGraph()
+
+	dot := history.RenderDOT()
+	assert.Contains(t, dot, "\"3000000\" [label=\"3000000 feat: \\\"b\\\"\\l(feat/b)\\l[v1.1.0-beta.2: 1.1.0.2 BETA]\\l\", style=filled, fillcolor=\"lightblue\"];\n")
+	assert.Contains(t, dot, "\"1000000\" [label=\"1000000 init\\l[v1.0.0: 1.0.0 FINAL] <- base of LATEST, CLOSEST\\l\", style=filled, fillcolor=\"lightblue\", penwidth=3];\n")
+	assert.Contains(t, dot, "\"3000000\" -> \"5000000\";\n")
+	assert.Contains(t, dot, "\"4000000\" -> \"5000000\";\n")
+}
+
+func TestGraphRenderMermaid(t *testing.T) {
+	history := newTestGraph()
+
+	mermaid := history.RenderMermaid()
+	assert.Contains(t, mermaid, "c3000000[\"3000000 feat: #quot;b#quot;<br/>(feat/b)<br/>[v1.1.0-beta.2: 1.1.0.2 BETA]\"]\n")
+	assert.Contains(t, mermaid, "c5000000[\"5000000 Merge feat/b<br/>(HEAD, develop)<br/>=#gt; LATEST: v1.1.0-develop.0, CLOSEST: v1.1.0-develop.0\"]\n")
+	assert.Contains(t, mermaid, "c2000000 --> c3000000\n")
+	assert.Contains(t, mermaid, "class c1000000 base\n")
+}
@@ -0,0 +1,54 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+)
+
+// escapeMermaid escapes a string for a quoted Mermaid label
+func escapeMermaid(str string) string {
+	return strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;").Replace(str)
+}
+
+// RenderMermaid renders the graph as Mermaid flowchart (e.g. for markdown
+// files on GitHub or GitLab)
+func (g *Graph) RenderMermaid() string {
+	str := "flowchart BT\n"
+
+	for _, commit := range g.Commits {
+		lines := []string{commit.ShortHash() + " " + commit.Subject}
+		lines = append(lines, g.getLabels(commit)...)
+
+		labels := []string{}
+		for _, line := range lines {
+			labels = append(labels, escapeMermaid(line))
+		}
+
+		str += fmt.Sprintf("  c%s[\"%s\"]\n", commit.ShortHash(), strings.Join(labels, "<br/>"))
+	}
+
+	for _, commit := range g.Commits {
+		for _, parent := range commit.Parents {
+			str += fmt.Sprintf("  c%s --> c%s\n", shortHash(parent), commit.ShortHash())
+		}
+	}
+
+	for _, commit := range g.Commits {
+		if len(commit.Tags) > 0 {
+			str += fmt.Sprintf("  class c%s tagged\n", commit.ShortHash())
+		}
+
+		for _, tag := range commit.Tags {
+			if len(g.getBaseStrategies(tag)) > 0 {
+				str += fmt.Sprintf("  class c%s base\n", commit.ShortHash())
+
+				break
+			}
+		}
+	}
+
+	str += "  classDef tagged fill:#add8e6\n"
+	str += "  classDef base stroke-width:3px\n"
+
+	return str
+}
@@ -0,0 +1,189 @@
+package semver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LogLevel specifies the severity of a log message
+type LogLevel int
+
+const (
+	LogLevelDebug LogLevel = 0
+	LogLevelInfo  LogLevel = 1
+	LogLevelWarn  LogLevel = 2
+	LogLevelError LogLevel = 3
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LogLevelDebug:
+		return "DEBUG"
+	case LogLevelInfo:
+		return "INFO"
+	case LogLevelWarn:
+		return "WARN"
+	case LogLevelError:
+		return "ERROR"
+	default:
+		return fmt.Sprintf("%d", int(l))
+	}
+}
+
+// ParseLogLevel parses a level name (e.g. 'debug')
+func ParseLogLevel(str string) (LogLevel, error) {
+	switch strings.ToUpper(str) {
+	case "DEBUG":
+		return LogLevelDebug, nil
+	case "INFO":
+		return LogLevelInfo, nil
+	case "WARN", "WARNING":
+		return LogLevelWarn, nil
+	case "ERROR":
+		return LogLevelError, nil
+	default:
+		return LogLevelWarn, fmt.Errorf("invalid log level \"%s\"", str)
+	}
+}
+
+// LogFormat specifies the format of log messages written by WriterLogger
+type LogFormat string
+
+const (
+	// LogFormatText writes messages like '[DEBUG] Found tag v1.0.0'
+	LogFormatText LogFormat = "text"
+	// LogFormatJSON writes one JSON object per message with the fields time,
+	// level and msg
+	LogFormatJSON LogFormat = "json"
+)
+
+// ParseLogFormat parses a log format name (e.g. 'json')
+func ParseLogFormat(str string) (LogFormat, error) {
+	switch LogFormat(strings.ToLower(str)) {
+	case LogFormatText:
+		return LogFormatText, nil
+	case LogFormatJSON:
+		return LogFormatJSON, nil
+	default:
+		return LogFormatText, fmt.Errorf("invalid log format \"%s\"", str)
+	}
+}
+
+// Logger receives the diagnostics of the library, the method set matches
+// common loggers (e.g. logrus or zap's SugaredLogger)
+type Logger interface {
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// WriterLogger writes all messages of at least its level to a writer
+type WriterLogger struct {
+	mutex  sync.Mutex
+	output io.Writer
+	level  LogLevel
+	format LogFormat
+	// now returns the timestamp of JSON messages
+	now func() time.Time
+}
+
+type jsonLogMessage struct {
+	Time  string `json:"time"`
+	Level string `json:"level"`
+	Msg   string `json:"msg"`
+}
+
+func (l *WriterLogger) log(level LogLevel, format string, args ...interface{}) {
+	if level < l.level {
+		return
+	}
+
+	msg := fmt.Sprintf(format, args...)
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	switch l.format {
+	case LogFormatJSON:
+		data, err := json.Marshal(&jsonLogMessage{
+			Time:  l.now().UTC().Format(time.RFC3339Nano),
+			Level: strings.ToLower(level.String()),
+			Msg:   msg,
+		})
+		if err != nil {
+			return
+		}
+
+		fmt.Fprintf(l.output, "%s\n", data)
+	default:
+		fmt.Fprintf(l.output, "[%s] %s\n", level, msg)
+	}
+}
+
+func (l *WriterLogger) Debugf(format string, args ...interface{}) {
+	l.log(LogLevelDebug, format, args...)
+}
+
+func (l *WriterLogger) Infof(format string, args ...interface{}) {
+	l.log(LogLevelInfo, format, args...)
+}
+
+func (l *WriterLogger) Warnf(format string, args ...interface{}) {
+	l.log(LogLevelWarn, format, args...)
+}
+
+func (l *WriterLogger) Errorf(format string, args ...interface{}) {
+	l.log(LogLevelError, format, args...)
+}
+
+// NewWriterLogger creates a logger writing all messages of at least level to
+// output
+func NewWriterLogger(output io.Writer, level LogLevel, format LogFormat) *WriterLogger {
+	return &WriterLogger{
+		output: output,
+		level:  level,
+		format: format,
+		now:    time.Now,
+	}
+}
+
+type nopLogger struct{}
+
+func (l *nopLogger) Debugf(format string, args ...interface{}) {}
+func (l *nopLogger) Infof(format string, args ...interface{})  {}
+func (l *nopLogger) Warnf(format string, args ...interface{})  {}
+func (l *nopLogger) Errorf(format string, args ...interface{}) {}
+
+// NopLogger discards all messages
+var NopLogger Logger = &nopLogger{}
+
+var defaultLogger Logger
+
+// SetDefaultLogger sets the logger used by components without an injected
+// logger and by Debugf
+func SetDefaultLogger(logger Logger) {
+	defaultLogger = logger
+}
+
+// GetDefaultLogger returns the logger set by SetDefaultLogger or NopLogger
+func GetDefaultLogger() Logger {
+	if defaultLogger != nil {
+		return defaultLogger
+	}
+
+	return NopLogger
+}
+
+// GetLogger returns logger or the default logger if logger is nil
+func GetLogger(logger Logger) Logger {
+	if logger == nil {
+		return GetDefaultLogger()
+	}
+
+	return logger
+}
@@ -0,0 +1,64 @@
+package semver
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriterLoggerText(t *testing.T) {
+	output := &bytes.Buffer{}
+	logger := NewWriterLogger(output, LogLevelInfo, LogFormatText)
+
+	logger.Debugf("Found tag %s", "v1.0.0")
+	logger.Infof("Computed version %s", "v1.1.0")
+	logger.Errorf("Failed")
+
+	assert.Equal(t, "[INFO] Computed version v1.1.0\n[ERROR] Failed\n", output.String())
+}
+
+func TestWriterLoggerJSON(t *testing.T) {
+	output := &bytes.Buffer{}
+	logger := NewWriterLogger(output, LogLevelDebug, LogFormatJSON)
+	logger.now = func() time.Time {
+		return time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	}
+
+	logger.Warnf("Tag \"%s\" skipped", "v1")
+
+	assert.Equal(t, `{"time":"2021-01-02T03:04:05Z","level":"warn","msg":"Tag \"v1\" skipped"}`+"\n", output.String())
+}
+
+func TestParseLogLevel(t *testing.T) {
+	level, err := ParseLogLevel("debug")
+	assert.NoError(t, err)
+	assert.Equal(t, LogLevelDebug, level)
+
+	level, err = ParseLogLevel("WARNING")
+	assert.NoError(t, err)
+	assert.Equal(t, LogLevelWarn, level)
+
+	_, err = ParseLogLevel("verbose")
+	assert.Error(t, err)
+
+	_, err = ParseLogFormat("xml")
+	assert.Error(t, err)
+}
+
+func TestDefaultLogger(t *testing.T) {
+	defer SetDefaultLogger(nil)
+
+	assert.Equal(t, NopLogger, GetDefaultLogger())
+
+	output := &bytes.Buffer{}
+	logger := NewWriterLogger(output, LogLevelDebug, LogFormatText)
+	SetDefaultLogger(logger)
+
+	Debugf("Message %d", 1)
+
+	assert.Equal(t, "[DEBUG] Message 1\n", output.String())
+	assert.Equal(t, Logger(logger), GetLogger(nil))
+	assert.Equal(t, NopLogger, GetLogger(NopLogger))
+}
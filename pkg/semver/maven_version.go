@@ -0,0 +1,300 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Known Maven qualifiers in ascending order, unknown qualifiers are greater
+// than all known qualifiers and compared lexically
+var mavenQualifiers = []string{"alpha", "beta", "milestone", "rc", "snapshot", "", "sp"}
+
+var mavenQualifierAliases = map[string]string{
+	"ga":      "",
+	"final":   "",
+	"release": "",
+	"cr":      "rc",
+}
+
+type mavenItem interface {
+	// compare compares the item with other, other may be nil
+	compare(other mavenItem) int
+	isNull() bool
+}
+
+type mavenIntItem string
+
+func (i mavenIntItem) isNull() bool {
+	return i == "0"
+}
+
+func (i mavenIntItem) compare(other mavenItem) int {
+	switch o := other.(type) {
+	case nil:
+		if i.isNull() {
+			return 0
+		}
+
+		return 1
+	case mavenIntItem:
+		if len(i) != len(o) {
+			return compareInts(len(i), len(o))
+		}
+
+		return strings.Compare(string(i), string(o))
+	default:
+		// 1.1 > 1-sp > 1-1
+		return 1
+	}
+}
+
+type mavenStringItem string
+
+func newMavenStringItem(value string, followedByDigit bool) mavenStringItem {
+	if followedByDigit && len(value) == 1 {
+		switch value {
+		case "a":
+			value = "alpha"
+		case "b":
+			value = "beta"
+		case "m":
+			value = "milestone"
+		}
+	}
+
+	if alias, ok := mavenQualifierAliases[value]; ok {
+		value = alias
+	}
+
+	return mavenStringItem(value)
+}
+
+func (s mavenStringItem) comparable() string {
+	for i, qualifier := range mavenQualifiers {
+		if string(s) == qualifier {
+			return string(rune('0' + i))
+		}
+	}
+
+	return string(rune('0'+len(mavenQualifiers))) + "-" + string(s)
+}
+
+func (s mavenStringItem) isNull() bool {
+	return s == ""
+}
+
+func (s mavenStringItem) compare(other mavenItem) int {
+	switch o := other.(type) {
+	case nil:
+		return strings.Compare(s.comparable(), mavenStringItem("").comparable())
+	case mavenStringItem:
+		return strings.Compare(s.comparable(), o.comparable())
+	default:
+		// 1.any < 1.1, 1-any < 1-1
+		return -1
+	}
+}
+
+type mavenListItem struct {
+	items []mavenItem
+}
+
+func (l *mavenListItem) isNull() bool {
+	return len(l.items) == 0
+}
+
+// normalize removes trailing null items (e.g. 1.0.0 => 1)
+func (l *mavenListItem) normalize() {
+	for i := len(l.items) - 1; i >= 0; i-- {
+		if l.items[i].isNull() {
+			l.items = append(l.items[:i], l.items[i+1:]...)
+		} else if _, ok := l.items[i].(*mavenListItem); !ok {
+			break
+		}
+	}
+}
+
+func (l *mavenListItem) compare(other mavenItem) int {
+	switch o := other.(type) {
+	case nil:
+		if len(l.items) == 0 {
+			return 0
+		}
+
+		return l.items[0].compare(nil)
+	case mavenIntItem:
+		return -1
+	case mavenStringItem:
+		return 1
+	case *mavenListItem:
+		for i := 0; i < len(l.items) || i < len(o.items); i++ {
+			var c int
+
+			switch {
+			case i >= len(l.items):
+				c = -o.items[i].compare(nil)
+			case i >= len(o.items):
+				c = l.items[i].compare(nil)
+			default:
+				c = l.items[i].compare(o.items[i])
+			}
+
+			if c != 0 {
+				return c
+			}
+		}
+
+		return 0
+	default:
+		return 0
+	}
+}
+
+func newMavenItem(isDigit bool, value string) mavenItem {
+	if isDigit {
+		value = strings.TrimLeft(value, "0")
+		if value == "" {
+			value = "0"
+		}
+
+		return mavenIntItem(value)
+	}
+
+	return newMavenStringItem(value, false)
+}
+
+// MavenVersion is a version ordered like Maven's ComparableVersion
+// (e.g. 1.0-alpha < 1.0-beta < 1.0-rc < 1.0-SNAPSHOT < 1.0 < 1.0-sp)
+type MavenVersion struct {
+	str   string
+	items *mavenListItem
+}
+
+// String returns the version as parsed
+func (v *MavenVersion) String() string {
+	return v.str
+}
+
+// IsSnapshot returns true if the version is a SNAPSHOT version
+func (v *MavenVersion) IsSnapshot() bool {
+	return strings.HasSuffix(strings.ToUpper(v.str), "SNAPSHOT")
+}
+
+// Compare compares the versions, returns -1 if v is lower than b, 1 if v is
+// greater than b and 0 if both are equal (e.g. 1.0 and 1.0.0-ga)
+func (v *MavenVersion) Compare(b *MavenVersion) int {
+	return v.items.compare(b.items)
+}
+
+// ParseMavenVersion parses a Maven version, every string is a valid version
+func ParseMavenVersion(str string) *MavenVersion {
+	version := strings.ToLower(str)
+
+	root := &mavenListItem{}
+	list := root
+	stack := []*mavenListItem{root}
+
+	startList := func() {
+		newList := &mavenListItem{}
+		list.items = append(list.items, newList)
+		list = newList
+		stack = append(stack, list)
+	}
+
+	isDigit := false
+	start := 0
+
+	for i := 0; i < len(version); i++ {
+		c := version[i]
+
+		switch {
+		case c == '.' || c == '-':
+			if i == start {
+				list.items = append(list.items, mavenIntItem("0"))
+			} else {
+				list.items = append(list.items, newMavenItem(isDigit, version[start:i]))
+			}
+
+			start = i + 1
+
+			if c == '-' {
+				startList()
+			}
+		case c >= '0' && c <= '9':
+			if !isDigit && i > start {
+				list.items = append(list.items, newMavenStringItem(version[start:i], true))
+				start = i
+
+				startList()
+			}
+
+			isDigit = true
+		default:
+			if isDigit && i > start {
+				list.items = append(list.items, newMavenItem(true, version[start:i]))
+				start = i
+
+				startList()
+			}
+
+			isDigit = false
+		}
+	}
+
+	if len(version) > start {
+		list.items = append(list.items, newMavenItem(isDigit, version[start:]))
+	}
+
+	for i := len(stack) - 1; i >= 0; i-- {
+		stack[i].normalize()
+	}
+
+	return &MavenVersion{
+		str:   str,
+		items: root,
+	}
+}
+
+// ParseMavenQualifier returns the release channel of a Maven qualifier
+// (e.g. 'beta-2' => BETA, 'SNAPSHOT' => none), unknown qualifiers belong to
+// no release channel, releaseChannels are the defined release channels
+// (default release channels if nil)
+func ParseMavenQualifier(qualifier string, releaseChannels *ReleaseChannels) ReleaseChannel {
+	name := getQualifierName(qualifier)
+
+	if alias, ok := mavenQualifierAliases[name]; ok {
+		name = alias
+	}
+
+	return parseQualifierName(name, releaseChannels)
+}
+
+type mavenScheme struct{}
+
+func (s *mavenScheme) GetName() string {
+	return VersionSchemeMaven
+}
+
+func (s *mavenScheme) Parse(version string) (SchemeVersion, error) {
+	if version == "" {
+		return nil, fmt.Errorf("empty maven version")
+	}
+
+	return ParseMavenVersion(version), nil
+}
+
+func (s *mavenScheme) Compare(a SchemeVersion, b SchemeVersion) int {
+	return a.(*MavenVersion).Compare(b.(*MavenVersion))
+}
+
+func (s *mavenScheme) GetQualifier(releaseChannel ReleaseChannel, releaseChannels *ReleaseChannels) string {
+	return getDashQualifier(releaseChannel, releaseChannels, "-SNAPSHOT")
+}
+
+func (s *mavenScheme) GetQualifierPattern() string {
+	return `(?:-[a-zA-Z]+)?`
+}
+
+func (s *mavenScheme) ParseQualifier(qualifier string, releaseChannels *ReleaseChannels) ReleaseChannel {
+	return ParseMavenQualifier(qualifier, releaseChannels)
+}
@@ -0,0 +1,68 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMavenVersionCompare(t *testing.T) {
+	// Ascending order
+	versions := []string{
+		"1-alpha",
+		"1-alpha2",
+		"1-alpha-10",
+		"1.0-beta",
+		"1.0-b2",
+		"1.0-milestone-1",
+		"1.0-rc1",
+		"1.0-cr2",
+		"1.0-SNAPSHOT",
+		"1",
+		"1.0-sp",
+		"1.0-whatever",
+		"1.0.1-SNAPSHOT",
+		"1.0.1",
+		"1.0.1.3",
+		"1.2",
+		"1.10",
+		"2.0-alpha",
+		"2.0",
+	}
+
+	for i := 0; i < len(versions)-1; i++ {
+		a := ParseMavenVersion(versions[i])
+		b := ParseMavenVersion(versions[i+1])
+
+		assert.Equal(t, -1, a.Compare(b), "%s < %s", versions[i], versions[i+1])
+		assert.Equal(t, 1, b.Compare(a), "%s > %s", versions[i+1], versions[i])
+	}
+
+	// Equal versions
+	equal := [][2]string{
+		{"1", "1.0.0"},
+		{"1.0", "1-ga"},
+		{"1.0-final", "1.0.0-RELEASE"},
+		{"1.0-rc1", "1.0-CR1"},
+		{"1.0-a1", "1.0-alpha-1"},
+		{"1.01", "1.1"},
+	}
+
+	for _, pair := range equal {
+		assert.Equal(t, 0, ParseMavenVersion(pair[0]).Compare(ParseMavenVersion(pair[1])), "%s == %s", pair[0], pair[1])
+	}
+}
+
+func TestMavenVersionIsSnapshot(t *testing.T) {
+	assert.True(t, ParseMavenVersion("1.3.0-SNAPSHOT").IsSnapshot())
+	assert.False(t, ParseMavenVersion("1.3.0-rc1").IsSnapshot())
+}
+
+func TestParseMavenQualifier(t *testing.T) {
+	assert.Equal(t, ReleaseChannelFinal, ParseMavenQualifier("", nil))
+	assert.Equal(t, ReleaseChannelFinal, ParseMavenQualifier("-RELEASE", nil))
+	assert.Equal(t, ReleaseChannelAlpha, ParseMavenQualifier("-alpha-2", nil))
+	assert.Equal(t, ReleaseChannelBeta, ParseMavenQualifier("-beta", nil))
+	assert.Equal(t, ReleaseChannelGamma, ParseMavenQualifier("-rc1", nil))
+	assert.Equal(t, ReleaseChannelNone, ParseMavenQualifier("-SNAPSHOT", nil))
+}
@@ -0,0 +1,156 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var expNumericVersion = regexp.MustCompile(`^(\d+(?:\.\d+)*)(?:-([0-9A-Za-z\-]+(?:\.[0-9A-Za-z\-]+)*))?(?:\+[0-9A-Za-z\-\.]+)?$`)
+
+// NumericVersion is a version consisting of any number of numeric components
+// and optional prerelease identifiers (e.g. '2024.03.1-beta.2'), used by the
+// CalVer and NuGet schemes
+type NumericVersion struct {
+	str string
+	// Components contains the numeric components, missing components are 0
+	Components []int
+	// Prerelease contains the dot-separated prerelease identifiers
+	Prerelease []string
+}
+
+// String returns the version as parsed
+func (v *NumericVersion) String() string {
+	return v.str
+}
+
+// Compare compares the versions by their components and prerelease
+// identifiers following the semver precedence rules
+func (v *NumericVersion) Compare(b *NumericVersion) int {
+	for i := 0; i < len(v.Components) || i < len(b.Components); i++ {
+		componentA := 0
+		if i < len(v.Components) {
+			componentA = v.Components[i]
+		}
+
+		componentB := 0
+		if i < len(b.Components) {
+			componentB = b.Components[i]
+		}
+
+		if c := compareInts(componentA, componentB); c != 0 {
+			return c
+		}
+	}
+
+	// A version without prerelease has a higher precedence
+	switch {
+	case len(v.Prerelease) == 0 && len(b.Prerelease) == 0:
+		return 0
+	case len(v.Prerelease) == 0:
+		return 1
+	case len(b.Prerelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.Prerelease) && i < len(b.Prerelease); i++ {
+		identifierA := v.Prerelease[i]
+		identifierB := b.Prerelease[i]
+		if isNumericIdentifier(identifierA) && isNumericIdentifier(identifierB) {
+			identifierA = trimLeadingZeros(identifierA)
+			identifierB = trimLeadingZeros(identifierB)
+		}
+
+		if c := comparePrereleaseIdentifiers(identifierA, identifierB); c != 0 {
+			return c
+		}
+	}
+
+	return compareInts(len(v.Prerelease), len(b.Prerelease))
+}
+
+func trimLeadingZeros(number string) string {
+	trimmed := strings.TrimLeft(number, "0")
+	if trimmed == "" {
+		return "0"
+	}
+
+	return trimmed
+}
+
+// ParseNumericVersion parses a version with numeric components
+func ParseNumericVersion(str string) (*NumericVersion, error) {
+	match := expNumericVersion.FindStringSubmatch(str)
+	if match == nil {
+		return nil, fmt.Errorf("invalid version \"%s\"", str)
+	}
+
+	version := &NumericVersion{
+		str:        str,
+		Components: []int{},
+		Prerelease: []string{},
+	}
+
+	for _, component := range strings.Split(match[1], ".") {
+		value, err := strconv.Atoi(component)
+		if err != nil {
+			return nil, fmt.Errorf("invalid component in \"%s\": %s", str, err)
+		}
+
+		version.Components = append(version.Components, value)
+	}
+
+	if match[2] != "" {
+		version.Prerelease = strings.Split(match[2], ".")
+	}
+
+	return version, nil
+}
+
+type numericScheme struct {
+	name string
+	// maxComponents is the maximum number of numeric components, 0 for no limit
+	maxComponents int
+	// ignoreCase compares prerelease identifiers case-insensitively
+	ignoreCase bool
+}
+
+func (s *numericScheme) GetName() string {
+	return s.name
+}
+
+func (s *numericScheme) Parse(version string) (SchemeVersion, error) {
+	numericVersion, err := ParseNumericVersion(version)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.maxComponents > 0 && len(numericVersion.Components) > s.maxComponents {
+		return nil, fmt.Errorf("invalid version \"%s\": more than %d components", version, s.maxComponents)
+	}
+
+	if s.ignoreCase {
+		for i, identifier := range numericVersion.Prerelease {
+			numericVersion.Prerelease[i] = strings.ToLower(identifier)
+		}
+	}
+
+	return numericVersion, nil
+}
+
+func (s *numericScheme) Compare(a SchemeVersion, b SchemeVersion) int {
+	return a.(*NumericVersion).Compare(b.(*NumericVersion))
+}
+
+func (s *numericScheme) GetQualifier(releaseChannel ReleaseChannel, releaseChannels *ReleaseChannels) string {
+	return getDashQualifier(releaseChannel, releaseChannels, "-dev")
+}
+
+func (s *numericScheme) GetQualifierPattern() string {
+	return `(?:-[a-zA-Z]+)?`
+}
+
+func (s *numericScheme) ParseQualifier(qualifier string, releaseChannels *ReleaseChannels) ReleaseChannel {
+	return parseQualifierName(getQualifierName(qualifier), releaseChannels)
+}
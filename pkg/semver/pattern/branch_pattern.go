@@ -1,7 +1,10 @@
-package main
+// Package pattern contains the branch and version patterns used to match
+// branch names and to parse and generate version tags.
+package pattern
 
 import "regexp"
 
+// BranchPattern matches branch names against a regular expression
 type BranchPattern struct {
 	exp *regexp.Regexp
 }
@@ -10,6 +13,7 @@ func (p *BranchPattern) Match(str string) bool {
 	return p.exp.MatchString(str)
 }
 
+// NewBranchPattern compiles a branch pattern (regular expression)
 func NewBranchPattern(pattern string) (*BranchPattern, error) {
 	exp, err := regexp.Compile(pattern)
 	if err != nil {
@@ -0,0 +1,277 @@
+package pattern
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/indece-official/semantic-version/pkg/semver"
+)
+
+var ExpCleanBranchName = regexp.MustCompile(`[^a-zA-Z0-9\-\_]`)
+
+// VersionPattern parses and generates version tags
+//
+// Placeholders:
+//
+//	{major} Major version
+//	{minor} Minor version
+//	{patch} Patch version
+//	{build} Build number
+//	{branch} Branch name
+//	{commit} Commit hash
+//	{shortcommit} Commit hash (short)
+//	{qualifier} Qualifier of the release channel in the version scheme
+//	            (e.g. -beta for semver, b for PEP 440, empty for FINAL)
+//	{yyyy} Full year (e.g. 2026)
+//	{yy} Short year (e.g. 26)
+//	{mm} Month (e.g. 3)
+//	{0m} Zero-padded month (e.g. 03)
+//	{ww} ISO week (e.g. 7), years are ISO week-numbering years then
+//	{dd} Day (e.g. 9)
+type VersionPattern struct {
+	releaseChannel  semver.ReleaseChannel
+	scheme          semver.VersionScheme
+	releaseChannels *semver.ReleaseChannels
+	pattern         string
+	exp             *regexp.Regexp
+}
+
+// Parse parses a tag, returns nil if the tag doesn't match the pattern
+func (p *VersionPattern) Parse(str string) *semver.VersionInfo {
+	versionInfo := &semver.VersionInfo{}
+
+	match := p.exp.FindStringSubmatch(str)
+	if match == nil {
+		return nil
+	}
+
+	versionInfo.ReleaseChannel = p.releaseChannel
+	versionInfo.Scheme = p.scheme
+	versionInfo.ReleaseChannels = p.releaseChannels
+
+	for i, name := range p.exp.SubexpNames() {
+		if i == 0 || name == "" {
+			continue
+		}
+
+		switch name {
+		case "major":
+			major, err := strconv.Atoi(match[i])
+			if err != nil {
+				return nil
+			}
+
+			versionInfo.Major = major
+		case "minor":
+			minor, err := strconv.Atoi(match[i])
+			if err != nil {
+				return nil
+			}
+
+			versionInfo.Minor = minor
+		case "patch":
+			patch, err := strconv.Atoi(match[i])
+			if err != nil {
+				return nil
+			}
+
+			versionInfo.Patch = patch
+		case "build":
+			build, err := strconv.Atoi(match[i])
+			if err != nil {
+				return nil
+			}
+
+			versionInfo.Build = build
+		case "branch":
+			versionInfo.Branch = match[i]
+		case "commit":
+			versionInfo.Commit = match[i]
+		case "shortcommit":
+			versionInfo.ShortCommit = match[i]
+		case "qualifier":
+			versionInfo.ReleaseChannel = p.scheme.ParseQualifier(match[i], p.releaseChannels)
+		case "year", "shortyear", "month", "zeromonth", "week", "day":
+			value, err := strconv.Atoi(match[i])
+			if err != nil {
+				return nil
+			}
+
+			switch name {
+			case "year":
+				versionInfo.Year = value
+			case "shortyear":
+				versionInfo.Year = 2000 + value
+			case "month", "zeromonth":
+				versionInfo.Month = value
+			case "week":
+				versionInfo.Week = value
+			case "day":
+				versionInfo.Day = value
+			}
+		}
+	}
+
+	return versionInfo
+}
+
+// Generate generates a tag for the version
+func (v *VersionPattern) Generate(info *semver.VersionInfo) string {
+	branch := ExpCleanBranchName.ReplaceAllString(info.Branch, "_")
+
+	str := v.pattern
+	str = strings.ReplaceAll(str, "{major}", fmt.Sprintf("%d", info.Major))
+	str = strings.ReplaceAll(str, "{minor}", fmt.Sprintf("%d", info.Minor))
+	str = strings.ReplaceAll(str, "{patch}", fmt.Sprintf("%d", info.Patch))
+	str = strings.ReplaceAll(str, "{build}", fmt.Sprintf("%d", info.Build))
+	str = strings.ReplaceAll(str, "{branch}", branch)
+	str = strings.ReplaceAll(str, "{commit}", info.Commit)
+	str = strings.ReplaceAll(str, "{shortcommit}", info.ShortCommit)
+	str = strings.ReplaceAll(str, "{qualifier}", v.scheme.GetQualifier(info.ReleaseChannel, v.releaseChannels))
+	str = strings.ReplaceAll(str, "{yyyy}", fmt.Sprintf("%04d", info.Year))
+	str = strings.ReplaceAll(str, "{yy}", fmt.Sprintf("%d", info.Year-2000))
+	str = strings.ReplaceAll(str, "{mm}", fmt.Sprintf("%d", info.Month))
+	str = strings.ReplaceAll(str, "{0m}", fmt.Sprintf("%02d", info.Month))
+	str = strings.ReplaceAll(str, "{ww}", fmt.Sprintf("%d", info.Week))
+	str = strings.ReplaceAll(str, "{dd}", fmt.Sprintf("%d", info.Day))
+
+	return str
+}
+
+// GenerateUnique generates a tag for the version not contained in usedTags by
+// incrementing the build number
+//
+// If the pattern doesn't use the build number, an error is returned unless
+// force is set.
+func (v *VersionPattern) GenerateUnique(info *semver.VersionInfo, usedTags map[string]bool, force bool) (string, error) {
+	newTag := v.Generate(info)
+	used, exists := usedTags[newTag]
+	if !exists || !used {
+		return newTag, nil
+	}
+
+	if !v.UsesBuild() {
+		if force {
+			return newTag, nil
+		}
+
+		// Iterating the build number doesn't make sens
+		return "", fmt.Errorf("can't generate unique version without violating rules")
+	}
+
+	for {
+		info.Build++
+		newTag = v.Generate(info)
+		used, exists = usedTags[newTag]
+		if !exists || !used {
+			return newTag, nil
+		}
+	}
+}
+
+// UsesBuild checks if the pattern contains the build number
+func (v *VersionPattern) UsesBuild() bool {
+	return strings.Contains(v.pattern, "{build}")
+}
+
+// UsesDate checks if the pattern contains date placeholders (calendar version)
+func (v *VersionPattern) UsesDate() bool {
+	for _, placeholder := range []string{"{yyyy}", "{yy}", "{mm}", "{0m}", "{ww}", "{dd}"} {
+		if strings.Contains(v.pattern, placeholder) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetMaxIncrementLevel returns the highest increment level whose number is
+// contained in the pattern (BUILD if the pattern contains none of {major},
+// {minor} and {patch})
+func (v *VersionPattern) GetMaxIncrementLevel() semver.VersionIncrementLevel {
+	switch {
+	case strings.Contains(v.pattern, "{major}"):
+		return semver.VersionIncrementLevelMajor
+	case strings.Contains(v.pattern, "{minor}"):
+		return semver.VersionIncrementLevelMinor
+	case strings.Contains(v.pattern, "{patch}"):
+		return semver.VersionIncrementLevelPatch
+	default:
+		return semver.VersionIncrementLevelBuild
+	}
+}
+
+// UsesWeek checks if the pattern contains the ISO week, the year of
+// calendar versions is the ISO week-numbering year then
+func (v *VersionPattern) UsesWeek() bool {
+	return strings.Contains(v.pattern, "{ww}")
+}
+
+// IsSamePeriod checks if the date components of both versions used by the
+// pattern are equal (e.g. year and month for '{yyyy}.{mm}.{patch}')
+func (v *VersionPattern) IsSamePeriod(a *semver.VersionInfo, b *semver.VersionInfo) bool {
+	if (strings.Contains(v.pattern, "{yyyy}") || strings.Contains(v.pattern, "{yy}")) && a.Year != b.Year {
+		return false
+	}
+
+	if (strings.Contains(v.pattern, "{mm}") || strings.Contains(v.pattern, "{0m}")) && a.Month != b.Month {
+		return false
+	}
+
+	if strings.Contains(v.pattern, "{ww}") && a.Week != b.Week {
+		return false
+	}
+
+	if strings.Contains(v.pattern, "{dd}") && a.Day != b.Day {
+		return false
+	}
+
+	return true
+}
+
+// NewVersionPattern compiles a version pattern for the release channel using
+// the semver scheme and the default release channels
+func NewVersionPattern(pattern string, releaseChannel semver.ReleaseChannel) (*VersionPattern, error) {
+	return NewSchemeVersionPattern(pattern, releaseChannel, semver.GetVersionScheme(semver.VersionSchemeSemver), nil)
+}
+
+// NewSchemeVersionPattern compiles a version pattern for the release channel
+// and version scheme, releaseChannels are the defined release channels
+// (default release channels if nil)
+func NewSchemeVersionPattern(pattern string, releaseChannel semver.ReleaseChannel, scheme semver.VersionScheme, releaseChannels *semver.ReleaseChannels) (*VersionPattern, error) {
+	expPattern := pattern
+	expPattern = strings.ReplaceAll(expPattern, "\\", "\\\\")
+	expPattern = strings.ReplaceAll(expPattern, "-", "\\-")
+	expPattern = strings.ReplaceAll(expPattern, ".", "\\.")
+	expPattern = strings.ReplaceAll(expPattern, "+", "\\+")
+	expPattern = strings.ReplaceAll(expPattern, "{major}", "(?P<major>\\d+)")
+	expPattern = strings.ReplaceAll(expPattern, "{minor}", "(?P<minor>\\d+)")
+	expPattern = strings.ReplaceAll(expPattern, "{patch}", "(?P<patch>\\d+)")
+	expPattern = strings.ReplaceAll(expPattern, "{build}", "(?P<build>\\d+)")
+	expPattern = strings.ReplaceAll(expPattern, "{branch}", "(?P<branch>[a-zA-Z0-9\\_\\-\\\\/\\(\\)\\[\\]]+)")
+	expPattern = strings.ReplaceAll(expPattern, "{commit}", "(?P<commit>[a-zA-Z0-9]+)")
+	expPattern = strings.ReplaceAll(expPattern, "{shortcommit}", "(?P<shortcommit>[a-zA-Z0-9]+)")
+	expPattern = strings.ReplaceAll(expPattern, "{qualifier}", fmt.Sprintf("(?P<qualifier>%s)", scheme.GetQualifierPattern()))
+	expPattern = strings.ReplaceAll(expPattern, "{yyyy}", "(?P<year>\\d{4})")
+	expPattern = strings.ReplaceAll(expPattern, "{yy}", "(?P<shortyear>\\d{1,3})")
+	expPattern = strings.ReplaceAll(expPattern, "{mm}", "(?P<month>\\d{1,2})")
+	expPattern = strings.ReplaceAll(expPattern, "{0m}", "(?P<zeromonth>\\d{2})")
+	expPattern = strings.ReplaceAll(expPattern, "{ww}", "(?P<week>\\d{1,2})")
+	expPattern = strings.ReplaceAll(expPattern, "{dd}", "(?P<day>\\d{1,2})")
+	expPattern = fmt.Sprintf("^%s$", expPattern)
+
+	exp, err := regexp.Compile(expPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VersionPattern{
+		releaseChannel:  releaseChannel,
+		scheme:          scheme,
+		releaseChannels: releaseChannels,
+		pattern:         pattern,
+		exp:             exp,
+	}, nil
+}
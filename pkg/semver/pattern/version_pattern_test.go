@@ -0,0 +1,214 @@
+package pattern
+
+import (
+	"testing"
+
+	"github.com/indece-official/semantic-version/pkg/semver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionPatternParse(t *testing.T) {
+	ptr, err := NewVersionPattern("v{major}.{minor}.{patch}-{branch}.{build}", semver.ReleaseChannelNone)
+	assert.NoError(t, err)
+	assert.NotNil(t, ptr)
+
+	version := ptr.Parse("v1.23.12-feat/stephan.13")
+	assert.NotNil(t, version)
+	assert.Equal(t, 1, version.Major)
+	assert.Equal(t, 23, version.Minor)
+	assert.Equal(t, 12, version.Patch)
+	assert.Equal(t, "feat/stephan", version.Branch)
+	assert.Equal(t, "", version.Commit)
+	assert.Equal(t, 13, version.Build)
+}
+
+func TestVersionPatternQualifier(t *testing.T) {
+	ptr, err := NewSchemeVersionPattern("{major}.{minor}.{patch}{qualifier}", semver.ReleaseChannelFinal, semver.GetVersionScheme(semver.VersionSchemeMaven), nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, ptr)
+
+	version := ptr.Parse("1.3.0-SNAPSHOT")
+	assert.NotNil(t, version)
+	assert.Equal(t, 3, version.Minor)
+	assert.Equal(t, semver.ReleaseChannelNone, version.ReleaseChannel)
+	assert.Equal(t, semver.VersionSchemeMaven, version.GetScheme().GetName())
+
+	version = ptr.Parse("1.3.0-RC")
+	assert.NotNil(t, version)
+	assert.Equal(t, semver.ReleaseChannelGamma, version.ReleaseChannel)
+
+	version = ptr.Parse("1.3.0")
+	assert.NotNil(t, version)
+	assert.Equal(t, semver.ReleaseChannelFinal, version.ReleaseChannel)
+
+	assert.Equal(t, "1.3.0-beta", ptr.Generate(&semver.VersionInfo{Major: 1, Minor: 3, ReleaseChannel: semver.ReleaseChannelBeta}))
+	assert.Equal(t, "1.3.0-SNAPSHOT", ptr.Generate(&semver.VersionInfo{Major: 1, Minor: 3}))
+	assert.Equal(t, "1.3.0", ptr.Generate(&semver.VersionInfo{Major: 1, Minor: 3, ReleaseChannel: semver.ReleaseChannelFinal}))
+}
+
+func TestVersionPatternPEP440(t *testing.T) {
+	ptr, err := NewSchemeVersionPattern("{major}.{minor}.{patch}{qualifier}{build}", semver.ReleaseChannelBeta, semver.GetVersionScheme(semver.VersionSchemePEP440), nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, ptr)
+
+	version := ptr.Parse("1.2.0rc1")
+	assert.NotNil(t, version)
+	assert.Equal(t, semver.ReleaseChannelGamma, version.ReleaseChannel)
+	assert.Equal(t, 1, version.Build)
+
+	version = ptr.Parse("1.2.0.dev4")
+	assert.NotNil(t, version)
+	assert.Equal(t, semver.ReleaseChannelNone, version.ReleaseChannel)
+	assert.Equal(t, 4, version.Build)
+
+	assert.Nil(t, ptr.Parse("1.2.0-beta1"))
+
+	assert.Equal(t, "1.2.0a3", ptr.Generate(&semver.VersionInfo{Major: 1, Minor: 2, Build: 3, ReleaseChannel: semver.ReleaseChannelAlpha}))
+	assert.Equal(t, "1.2.0.dev4", ptr.Generate(&semver.VersionInfo{Major: 1, Minor: 2, Build: 4}))
+}
+
+func TestVersionGenerate(t *testing.T) {
+	ptr, err := NewVersionPattern("v{major}.{minor}.{patch}-{branch}.{build}", semver.ReleaseChannelNone)
+	assert.NoError(t, err)
+	assert.NotNil(t, ptr)
+
+	versionInfo := &semver.VersionInfo{
+		Major:  2,
+		Minor:  12,
+		Patch:  56,
+		Build:  0,
+		Branch: "feat/stephan",
+		Commit: "abcdef12345",
+	}
+
+	version := ptr.Generate(versionInfo)
+	assert.Equal(t, "v2.12.56-feat_stephan.0", version)
+}
+
+func TestVersionGenerateShortCommit(t *testing.T) {
+	ptr, err := NewVersionPattern("v{major}.{minor}.{patch}-{shortcommit}.{build}", semver.ReleaseChannelNone)
+	assert.NoError(t, err)
+	assert.NotNil(t, ptr)
+
+	versionInfo := &semver.VersionInfo{
+		Major:       2,
+		Minor:       12,
+		Patch:       56,
+		Build:       0,
+		Branch:      "feat/stephan",
+		ShortCommit: "abcdef12345",
+	}
+
+	version := ptr.Generate(versionInfo)
+	assert.Equal(t, "v2.12.56-abcdef12345.0", version)
+}
+
+func TestVersionGenerateUnique(t *testing.T) {
+	ptr, err := NewVersionPattern("v{major}.{minor}.{patch}-{branch}.{build}", semver.ReleaseChannelNone)
+	assert.NoError(t, err)
+	assert.NotNil(t, ptr)
+
+	versionInfo := &semver.VersionInfo{
+		Major:  2,
+		Minor:  12,
+		Patch:  56,
+		Build:  0,
+		Branch: "feat/stephan",
+		Commit: "abcdef12345",
+	}
+
+	usedTags := map[string]bool{
+		"v2.12.56":                true,
+		"v2.12.56-feat_stephan.0": true,
+		"v2.12.56-feat_stephan.1": true,
+		"v2.12.56-feat_stephan.2": true,
+	}
+
+	version, err := ptr.GenerateUnique(versionInfo, usedTags, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "v2.12.56-feat_stephan.3", version)
+}
+
+func TestVersionGenerateUniqueNotPossible(t *testing.T) {
+	ptr, err := NewVersionPattern("v{major}.{minor}.{patch}", semver.ReleaseChannelFinal)
+	assert.NoError(t, err)
+	assert.NotNil(t, ptr)
+
+	versionInfo := &semver.VersionInfo{
+		Major:  2,
+		Minor:  12,
+		Patch:  56,
+		Build:  0,
+		Branch: "master",
+		Commit: "abcdef12345",
+	}
+
+	usedTags := map[string]bool{
+		"v2.12.56":                true,
+		"v2.12.56-feat_stephan.0": true,
+		"v2.12.56-feat_stephan.1": true,
+		"v2.12.56-feat_stephan.2": true,
+	}
+
+	_, err = ptr.GenerateUnique(versionInfo, usedTags, false)
+	assert.Error(t, err)
+}
+
+func TestVersionGenerateUniqueNotPossibleForce(t *testing.T) {
+	ptr, err := NewVersionPattern("v{major}.{minor}.{patch}", semver.ReleaseChannelFinal)
+	assert.NoError(t, err)
+	assert.NotNil(t, ptr)
+
+	versionInfo := &semver.VersionInfo{
+		Major:  2,
+		Minor:  12,
+		Patch:  56,
+		Build:  0,
+		Branch: "master",
+		Commit: "abcdef12345",
+	}
+
+	usedTags := map[string]bool{
+		"v2.12.56":                true,
+		"v2.12.56-feat_stephan.0": true,
+		"v2.12.56-feat_stephan.1": true,
+		"v2.12.56-feat_stephan.2": true,
+	}
+
+	version, err := ptr.GenerateUnique(versionInfo, usedTags, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "v2.12.56", version)
+}
+
+func TestVersionPatternCalendar(t *testing.T) {
+	ptr, err := NewVersionPattern("v{yyyy}.{0m}.{patch}", semver.ReleaseChannelFinal)
+	assert.NoError(t, err)
+	assert.True(t, ptr.UsesDate())
+
+	version := ptr.Parse("v2024.03.2")
+	assert.NotNil(t, version)
+	assert.Equal(t, 2024, version.Year)
+	assert.Equal(t, 3, version.Month)
+	assert.Equal(t, 2, version.Patch)
+
+	assert.Nil(t, ptr.Parse("v2024.3.2"))
+
+	assert.Equal(t, "v2024.03.2", ptr.Generate(version))
+
+	ptr, err = NewVersionPattern("{yy}.{ww}.{dd}.{patch}", semver.ReleaseChannelFinal)
+	assert.NoError(t, err)
+
+	version = ptr.Parse("24.9.1.0")
+	assert.NotNil(t, version)
+	assert.Equal(t, 2024, version.Year)
+	assert.Equal(t, 9, version.Week)
+	assert.Equal(t, 1, version.Day)
+
+	assert.Equal(t, semver.VersionIncrementLevelPatch, ptr.GetMaxIncrementLevel())
+	assert.True(t, ptr.IsSamePeriod(version, &semver.VersionInfo{Year: 2024, Month: 3, Week: 9, Day: 1}))
+	assert.False(t, ptr.IsSamePeriod(version, &semver.VersionInfo{Year: 2024, Week: 9, Day: 2}))
+
+	ptr, err = NewVersionPattern("v{major}.{minor}.{patch}", semver.ReleaseChannelFinal)
+	assert.NoError(t, err)
+	assert.False(t, ptr.UsesDate())
+}
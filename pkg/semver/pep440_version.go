@@ -0,0 +1,241 @@
+package semver
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// expPEP440Version matches a PEP 440 version (see
+// https://peps.python.org/pep-0440/#appendix-b-parsing-version-strings-with-regular-expressions)
+var expPEP440Version = regexp.MustCompile(`(?i)^v?(?:(?P<epoch>\d+)!)?(?P<release>\d+(?:\.\d+)*)` +
+	`(?:[-_\.]?(?P<pre>alpha|a|beta|b|preview|pre|c|rc)[-_\.]?(?P<prenumber>\d+)?)?` +
+	`(?:-(?P<postimplicit>\d+)|[-_\.]?(?P<post>post|rev|r)[-_\.]?(?P<postnumber>\d+)?)?` +
+	`(?:[-_\.]?(?P<dev>dev)[-_\.]?(?P<devnumber>\d+)?)?` +
+	`(?:\+(?P<local>[a-z0-9]+(?:[-_\.][a-z0-9]+)*))?$`)
+
+// PEP440Version is a version following PEP 440 (e.g. '1.2.0rc1',
+// '1.2.0.post1', '1.2.0.dev4')
+type PEP440Version struct {
+	str     string
+	Epoch   int
+	Release []int
+	// Pre is the prerelease phase ('a', 'b' or 'rc'), empty for no prerelease
+	Pre       string
+	PreNumber int
+	// Post is the number of the post-release, -1 for no post-release
+	Post int
+	// Dev is the number of the development release, -1 for no development release
+	Dev   int
+	Local string
+}
+
+// String returns the version as parsed
+func (v *PEP440Version) String() string {
+	return v.str
+}
+
+// getPreKey returns the sort key of the prerelease part: development releases
+// without prerelease sort before all prereleases, final releases after
+func (v *PEP440Version) getPreKey() (int, int) {
+	switch {
+	case v.Pre == "" && v.Post < 0 && v.Dev >= 0:
+		return -1, 0
+	case v.Pre == "":
+		return math.MaxInt32, 0
+	case v.Pre == "a":
+		return 0, v.PreNumber
+	case v.Pre == "b":
+		return 1, v.PreNumber
+	default:
+		return 2, v.PreNumber
+	}
+}
+
+func (v *PEP440Version) getDevKey() int {
+	if v.Dev < 0 {
+		return math.MaxInt32
+	}
+
+	return v.Dev
+}
+
+// Compare compares the versions following the PEP 440 ordering
+// (e.g. 1.0.dev1 < 1.0a1 < 1.0b1 < 1.0rc1 < 1.0 < 1.0.post1)
+func (v *PEP440Version) Compare(b *PEP440Version) int {
+	if c := compareInts(v.Epoch, b.Epoch); c != 0 {
+		return c
+	}
+
+	for i := 0; i < len(v.Release) || i < len(b.Release); i++ {
+		componentA := 0
+		if i < len(v.Release) {
+			componentA = v.Release[i]
+		}
+
+		componentB := 0
+		if i < len(b.Release) {
+			componentB = b.Release[i]
+		}
+
+		if c := compareInts(componentA, componentB); c != 0 {
+			return c
+		}
+	}
+
+	preA, preNumberA := v.getPreKey()
+	preB, preNumberB := b.getPreKey()
+
+	if c := compareInts(preA, preB); c != 0 {
+		return c
+	}
+
+	if c := compareInts(preNumberA, preNumberB); c != 0 {
+		return c
+	}
+
+	if c := compareInts(v.Post, b.Post); c != 0 {
+		return c
+	}
+
+	if c := compareInts(v.getDevKey(), b.getDevKey()); c != 0 {
+		return c
+	}
+
+	// A version with local label is greater than the same version without
+	switch {
+	case v.Local == "" && b.Local == "":
+		return 0
+	case v.Local == "":
+		return -1
+	case b.Local == "":
+		return 1
+	default:
+		return strings.Compare(v.Local, b.Local)
+	}
+}
+
+func parsePEP440Number(str string, defaultValue int) (int, error) {
+	if str == "" {
+		return defaultValue, nil
+	}
+
+	return strconv.Atoi(str)
+}
+
+// ParsePEP440Version parses a PEP 440 version
+func ParsePEP440Version(str string) (*PEP440Version, error) {
+	match := expPEP440Version.FindStringSubmatch(str)
+	if match == nil {
+		return nil, fmt.Errorf("invalid PEP 440 version \"%s\"", str)
+	}
+
+	group := func(name string) string {
+		return match[expPEP440Version.SubexpIndex(name)]
+	}
+
+	version := &PEP440Version{
+		str:     str,
+		Release: []int{},
+		Post:    -1,
+		Dev:     -1,
+		Local:   strings.ToLower(group("local")),
+	}
+
+	var err error
+
+	version.Epoch, err = parsePEP440Number(group("epoch"), 0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid epoch in \"%s\": %s", str, err)
+	}
+
+	for _, component := range strings.Split(group("release"), ".") {
+		value, err := strconv.Atoi(component)
+		if err != nil {
+			return nil, fmt.Errorf("invalid release in \"%s\": %s", str, err)
+		}
+
+		version.Release = append(version.Release, value)
+	}
+
+	switch strings.ToLower(group("pre")) {
+	case "a", "alpha":
+		version.Pre = "a"
+	case "b", "beta":
+		version.Pre = "b"
+	case "c", "rc", "pre", "preview":
+		version.Pre = "rc"
+	}
+
+	version.PreNumber, err = parsePEP440Number(group("prenumber"), 0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid prerelease in \"%s\": %s", str, err)
+	}
+
+	if group("postimplicit") != "" {
+		version.Post, err = parsePEP440Number(group("postimplicit"), 0)
+	} else if group("post") != "" {
+		version.Post, err = parsePEP440Number(group("postnumber"), 0)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid post-release in \"%s\": %s", str, err)
+	}
+
+	if group("dev") != "" {
+		version.Dev, err = parsePEP440Number(group("devnumber"), 0)
+		if err != nil {
+			return nil, fmt.Errorf("invalid development release in \"%s\": %s", str, err)
+		}
+	}
+
+	return version, nil
+}
+
+type pep440Scheme struct{}
+
+func (s *pep440Scheme) GetName() string {
+	return VersionSchemePEP440
+}
+
+func (s *pep440Scheme) Parse(version string) (SchemeVersion, error) {
+	parsedVersion, err := ParsePEP440Version(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsedVersion, nil
+}
+
+func (s *pep440Scheme) Compare(a SchemeVersion, b SchemeVersion) int {
+	return a.(*PEP440Version).Compare(b.(*PEP440Version))
+}
+
+func (s *pep440Scheme) GetQualifier(releaseChannel ReleaseChannel, releaseChannels *ReleaseChannels) string {
+	switch releaseChannel {
+	case ReleaseChannelAlpha:
+		return "a"
+	case ReleaseChannelBeta:
+		return "b"
+	case ReleaseChannelGamma:
+		return "rc"
+	case ReleaseChannelFinal:
+		return ""
+	default:
+		return ".dev"
+	}
+}
+
+func (s *pep440Scheme) GetQualifierPattern() string {
+	return `(?:a|b|rc|\.dev|\.post)?`
+}
+
+func (s *pep440Scheme) ParseQualifier(qualifier string, releaseChannels *ReleaseChannels) ReleaseChannel {
+	name := getQualifierName(qualifier)
+	if name == "post" {
+		return ReleaseChannelFinal
+	}
+
+	return parseQualifierName(name, releaseChannels)
+}
@@ -0,0 +1,139 @@
+package publish
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"strings"
+)
+
+// apiError is returned for responses with an error status code
+type apiError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("status %d: %s", e.StatusCode, e.Message)
+}
+
+// isNotFound checks if err is an api error with status 404
+func isNotFound(err error) bool {
+	var apiErr *apiError
+
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// apiClient sends requests to a REST API
+type apiClient struct {
+	baseURL    string
+	header     http.Header
+	httpClient *http.Client
+}
+
+// send sends a request to url (absolute or relative to the base url) and
+// decodes the JSON response into result if not nil
+func (c *apiClient) send(method string, url string, contentType string, body io.Reader, result interface{}) error {
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		url = c.baseURL + url
+	}
+
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return fmt.Errorf("can't create request %s %s: %s", method, url, err)
+	}
+
+	for key, values := range c.header {
+		req.Header[key] = values
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request %s %s failed: %s", method, url, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("can't read response of %s %s: %s", method, url, err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &apiError{
+			StatusCode: resp.StatusCode,
+			Message:    strings.TrimSpace(string(data)),
+		}
+	}
+
+	if result == nil || len(data) == 0 {
+		return nil
+	}
+
+	err = json.Unmarshal(data, result)
+	if err != nil {
+		return fmt.Errorf("can't decode response of %s %s: %s", method, url, err)
+	}
+
+	return nil
+}
+
+// sendJSON sends a request with a JSON encoded body (if not nil)
+func (c *apiClient) sendJSON(method string, url string, body interface{}, result interface{}) error {
+	if body == nil {
+		return c.send(method, url, "", nil, result)
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("can't encode request: %s", err)
+	}
+
+	return c.send(method, url, "application/json", bytes.NewReader(data), result)
+}
+
+// sendFile sends a multipart form containing the file in field
+func (c *apiClient) sendFile(method string, url string, field string, name string, content []byte, result interface{}) error {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile(field, name)
+	if err != nil {
+		return fmt.Errorf("can't create form: %s", err)
+	}
+
+	_, err = part.Write(content)
+	if err != nil {
+		return fmt.Errorf("can't create form: %s", err)
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return fmt.Errorf("can't create form: %s", err)
+	}
+
+	return c.send(method, url, writer.FormDataContentType(), body, result)
+}
+
+func newAPIClient(baseURL string, header http.Header, options *ProviderOptions) *apiClient {
+	httpClient := options.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &apiClient{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		header:     header,
+		httpClient: httpClient,
+	}
+}
@@ -0,0 +1,81 @@
+package publish
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/indece-official/semantic-version/pkg/semver/config"
+)
+
+// GiteaProvider manages releases using the Gitea (or Forgejo) REST API
+type GiteaProvider struct {
+	client     *apiClient
+	repository string
+}
+
+func (p *GiteaProvider) GetRelease(tag string) (*Release, error) {
+	result := &githubRelease{}
+
+	err := p.client.sendJSON(http.MethodGet, fmt.Sprintf("/repos/%s/releases/tags/%s", p.repository, url.PathEscape(tag)), nil, result)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, ErrReleaseNotFound
+		}
+
+		return nil, err
+	}
+
+	return result.toRelease(), nil
+}
+
+func (p *GiteaProvider) CreateRelease(release *Release) (*Release, error) {
+	result := &githubRelease{}
+
+	err := p.client.sendJSON(http.MethodPost, fmt.Sprintf("/repos/%s/releases", p.repository), newGitHubRelease(release), result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.toRelease(), nil
+}
+
+func (p *GiteaProvider) UpdateRelease(release *Release) (*Release, error) {
+	result := &githubRelease{}
+
+	err := p.client.sendJSON(http.MethodPatch, fmt.Sprintf("/repos/%s/releases/%s", p.repository, release.ID), newGitHubRelease(release), result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.toRelease(), nil
+}
+
+func (p *GiteaProvider) UploadAsset(release *Release, name string, content []byte) error {
+	return p.client.sendFile(
+		http.MethodPost,
+		fmt.Sprintf("/repos/%s/releases/%s/assets?name=%s", p.repository, release.ID, url.QueryEscape(name)),
+		"attachment",
+		name,
+		content,
+		nil,
+	)
+}
+
+func (p *GiteaProvider) DeleteAsset(release *Release, asset *Asset) error {
+	return p.client.sendJSON(http.MethodDelete, fmt.Sprintf("/repos/%s/releases/%s/assets/%s", p.repository, release.ID, asset.ID), nil, nil)
+}
+
+// NewGiteaProvider creates a new provider for the Gitea instance of the
+// configured API url (e.g. 'https://gitea.example.com/api/v1')
+func NewGiteaProvider(cfg *config.PublishConfig, options *ProviderOptions) (Provider, error) {
+	header := http.Header{}
+	if options.Token != "" {
+		header.Set("Authorization", "token "+options.Token)
+	}
+
+	return &GiteaProvider{
+		client:     newAPIClient(cfg.URL, header, options),
+		repository: cfg.Repository,
+	}, nil
+}
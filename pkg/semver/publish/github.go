@@ -0,0 +1,138 @@
+package publish
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/indece-official/semantic-version/pkg/semver/config"
+)
+
+// githubAsset is an asset in responses of the GitHub and Gitea API
+type githubAsset struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// githubRelease is the release model of the GitHub and Gitea API
+type githubRelease struct {
+	ID         int64          `json:"id,omitempty"`
+	TagName    string         `json:"tag_name"`
+	Name       string         `json:"name"`
+	Body       string         `json:"body"`
+	Prerelease bool           `json:"prerelease"`
+	HTMLURL    string         `json:"html_url,omitempty"`
+	UploadURL  string         `json:"upload_url,omitempty"`
+	Assets     []*githubAsset `json:"assets,omitempty"`
+}
+
+func newGitHubRelease(release *Release) *githubRelease {
+	return &githubRelease{
+		TagName:    release.Tag,
+		Name:       release.Name,
+		Body:       release.Body,
+		Prerelease: release.Prerelease,
+	}
+}
+
+func (r *githubRelease) toRelease() *Release {
+	release := &Release{
+		ID:         strconv.FormatInt(r.ID, 10),
+		Tag:        r.TagName,
+		Name:       r.Name,
+		Body:       r.Body,
+		Prerelease: r.Prerelease,
+		Assets:     []*Asset{},
+		URL:        r.HTMLURL,
+		// Strip the URI template (e.g. '{?name,label}')
+		uploadURL: strings.SplitN(r.UploadURL, "{", 2)[0],
+	}
+
+	for _, asset := range r.Assets {
+		release.Assets = append(release.Assets, &Asset{
+			ID:   strconv.FormatInt(asset.ID, 10),
+			Name: asset.Name,
+		})
+	}
+
+	return release
+}
+
+// GitHubProvider manages releases using the GitHub REST API
+type GitHubProvider struct {
+	client     *apiClient
+	repository string
+}
+
+func (p *GitHubProvider) GetRelease(tag string) (*Release, error) {
+	result := &githubRelease{}
+
+	err := p.client.sendJSON(http.MethodGet, fmt.Sprintf("/repos/%s/releases/tags/%s", p.repository, url.PathEscape(tag)), nil, result)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, ErrReleaseNotFound
+		}
+
+		return nil, err
+	}
+
+	return result.toRelease(), nil
+}
+
+func (p *GitHubProvider) CreateRelease(release *Release) (*Release, error) {
+	result := &githubRelease{}
+
+	err := p.client.sendJSON(http.MethodPost, fmt.Sprintf("/repos/%s/releases", p.repository), newGitHubRelease(release), result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.toRelease(), nil
+}
+
+func (p *GitHubProvider) UpdateRelease(release *Release) (*Release, error) {
+	result := &githubRelease{}
+
+	err := p.client.sendJSON(http.MethodPatch, fmt.Sprintf("/repos/%s/releases/%s", p.repository, release.ID), newGitHubRelease(release), result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.toRelease(), nil
+}
+
+func (p *GitHubProvider) UploadAsset(release *Release, name string, content []byte) error {
+	if release.uploadURL == "" {
+		return fmt.Errorf("missing upload url of release %s", release.Tag)
+	}
+
+	return p.client.send(
+		http.MethodPost,
+		fmt.Sprintf("%s?name=%s", release.uploadURL, url.QueryEscape(name)),
+		"application/octet-stream",
+		bytes.NewReader(content),
+		nil,
+	)
+}
+
+func (p *GitHubProvider) DeleteAsset(release *Release, asset *Asset) error {
+	return p.client.sendJSON(http.MethodDelete, fmt.Sprintf("/repos/%s/releases/assets/%s", p.repository, asset.ID), nil, nil)
+}
+
+// NewGitHubProvider creates a new provider for GitHub (or GitHub Enterprise
+// if the url of its API is configured)
+func NewGitHubProvider(cfg *config.PublishConfig, options *ProviderOptions) (Provider, error) {
+	header := http.Header{}
+	header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if options.Token != "" {
+		header.Set("Authorization", "Bearer "+options.Token)
+	}
+
+	return &GitHubProvider{
+		client:     newAPIClient(cfg.URL, header, options),
+		repository: cfg.Repository,
+	}, nil
+}
@@ -0,0 +1,159 @@
+package publish
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/indece-official/semantic-version/pkg/semver/config"
+)
+
+type gitlabLink struct {
+	ID   int64  `json:"id,omitempty"`
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type gitlabRelease struct {
+	TagName     string `json:"tag_name"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Links       *struct {
+		Self string `json:"self"`
+	} `json:"_links,omitempty"`
+	Assets *struct {
+		Links []*gitlabLink `json:"links"`
+	} `json:"assets,omitempty"`
+}
+
+func (r *gitlabRelease) toRelease() *Release {
+	release := &Release{
+		ID:     r.TagName,
+		Tag:    r.TagName,
+		Name:   r.Name,
+		Body:   r.Description,
+		Assets: []*Asset{},
+	}
+
+	if r.Links != nil {
+		release.URL = r.Links.Self
+	}
+
+	if r.Assets != nil {
+		for _, link := range r.Assets.Links {
+			release.Assets = append(release.Assets, &Asset{
+				ID:   strconv.FormatInt(link.ID, 10),
+				Name: link.Name,
+			})
+		}
+	}
+
+	return release
+}
+
+type gitlabUpload struct {
+	URL      string `json:"url"`
+	FullPath string `json:"full_path"`
+}
+
+// GitLabProvider manages releases using the GitLab REST API
+//
+// GitLab has no prerelease flag, assets are uploaded to the project and
+// linked in the release.
+type GitLabProvider struct {
+	client     *apiClient
+	repository string
+	// webURL is the url of the GitLab instance (API url without '/api/v4')
+	webURL string
+}
+
+// getProjectURL returns the API path of the project
+func (p *GitLabProvider) getProjectURL() string {
+	return "/projects/" + url.PathEscape(p.repository)
+}
+
+func (p *GitLabProvider) GetRelease(tag string) (*Release, error) {
+	result := &gitlabRelease{}
+
+	err := p.client.sendJSON(http.MethodGet, fmt.Sprintf("%s/releases/%s", p.getProjectURL(), url.PathEscape(tag)), nil, result)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, ErrReleaseNotFound
+		}
+
+		return nil, err
+	}
+
+	return result.toRelease(), nil
+}
+
+func (p *GitLabProvider) CreateRelease(release *Release) (*Release, error) {
+	result := &gitlabRelease{}
+
+	err := p.client.sendJSON(http.MethodPost, fmt.Sprintf("%s/releases", p.getProjectURL()), &gitlabRelease{
+		TagName:     release.Tag,
+		Name:        release.Name,
+		Description: release.Body,
+	}, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.toRelease(), nil
+}
+
+func (p *GitLabProvider) UpdateRelease(release *Release) (*Release, error) {
+	result := &gitlabRelease{}
+
+	err := p.client.sendJSON(http.MethodPut, fmt.Sprintf("%s/releases/%s", p.getProjectURL(), url.PathEscape(release.Tag)), &gitlabRelease{
+		TagName:     release.Tag,
+		Name:        release.Name,
+		Description: release.Body,
+	}, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.toRelease(), nil
+}
+
+func (p *GitLabProvider) UploadAsset(release *Release, name string, content []byte) error {
+	upload := &gitlabUpload{}
+
+	err := p.client.sendFile(http.MethodPost, fmt.Sprintf("%s/uploads", p.getProjectURL()), "file", name, content, upload)
+	if err != nil {
+		return err
+	}
+
+	// Older GitLab versions return only the url relative to the project
+	linkURL := p.webURL + upload.FullPath
+	if upload.FullPath == "" {
+		linkURL = p.webURL + "/" + p.repository + upload.URL
+	}
+
+	return p.client.sendJSON(http.MethodPost, fmt.Sprintf("%s/releases/%s/assets/links", p.getProjectURL(), url.PathEscape(release.Tag)), &gitlabLink{
+		Name: name,
+		URL:  linkURL,
+	}, nil)
+}
+
+func (p *GitLabProvider) DeleteAsset(release *Release, asset *Asset) error {
+	return p.client.sendJSON(http.MethodDelete, fmt.Sprintf("%s/releases/%s/assets/links/%s", p.getProjectURL(), url.PathEscape(release.Tag), asset.ID), nil, nil)
+}
+
+// NewGitLabProvider creates a new provider for GitLab (or a self-hosted
+// instance if the url of its API is configured)
+func NewGitLabProvider(cfg *config.PublishConfig, options *ProviderOptions) (Provider, error) {
+	header := http.Header{}
+	if options.Token != "" {
+		header.Set("PRIVATE-TOKEN", options.Token)
+	}
+
+	return &GitLabProvider{
+		client:     newAPIClient(cfg.URL, header, options),
+		repository: cfg.Repository,
+		webURL:     strings.TrimSuffix(strings.TrimSuffix(cfg.URL, "/"), "/api/v4"),
+	}, nil
+}
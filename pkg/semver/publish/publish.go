@@ -0,0 +1,198 @@
+// Package publish publishes releases with their changelog and assets on
+// hosting services (GitHub, GitLab, Gitea).
+package publish
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+
+	"github.com/indece-official/semantic-version/pkg/semver"
+	"github.com/indece-official/semantic-version/pkg/semver/config"
+)
+
+// ErrReleaseNotFound is returned by providers if no release exists for a tag
+var ErrReleaseNotFound = errors.New("release not found")
+
+// Asset is a file attached to a release
+type Asset struct {
+	ID   string
+	Name string
+}
+
+// Release is a release of a tag on a hosting service
+type Release struct {
+	// ID identifies the release on the hosting service, empty for new releases
+	ID   string
+	Tag  string
+	Name string
+	// Body is the description of the release (changelog)
+	Body       string
+	Prerelease bool
+	Assets     []*Asset
+	// URL is the web url of the release
+	URL string
+
+	// uploadURL is the url assets are uploaded to (GitHub)
+	uploadURL string
+}
+
+// GetAsset returns the asset with the name, nil if it doesn't exist
+func (r *Release) GetAsset(name string) *Asset {
+	for _, asset := range r.Assets {
+		if asset.Name == name {
+			return asset
+		}
+	}
+
+	return nil
+}
+
+// Provider manages the releases of a repository on a hosting service
+type Provider interface {
+	// GetRelease returns the release of a tag, ErrReleaseNotFound is returned
+	// if no release exists
+	GetRelease(tag string) (*Release, error)
+	CreateRelease(release *Release) (*Release, error)
+	// UpdateRelease updates the name, body and prerelease flag of an existing
+	// release (identified by ID and Tag)
+	UpdateRelease(release *Release) (*Release, error)
+	UploadAsset(release *Release, name string, content []byte) error
+	DeleteAsset(release *Release, asset *Asset) error
+}
+
+// ProviderOptions contains optional settings of a provider
+type ProviderOptions struct {
+	// Token is the access token used to authenticate against the API
+	Token string
+	// HTTPClient is used for all requests, default is http.DefaultClient
+	HTTPClient *http.Client
+}
+
+// ProviderFactory creates a provider for a publish config
+type ProviderFactory func(cfg *config.PublishConfig, options *ProviderOptions) (Provider, error)
+
+var providerFactories = map[config.PublishProvider]ProviderFactory{
+	config.PublishProviderGitHub: NewGitHubProvider,
+	config.PublishProviderGitLab: NewGitLabProvider,
+	config.PublishProviderGitea:  NewGiteaProvider,
+}
+
+// RegisterProvider registers the provider factory for a hosting service,
+// replacing built-in providers of the same name
+func RegisterProvider(name config.PublishProvider, factory ProviderFactory) {
+	providerFactories[name] = factory
+}
+
+// NewProvider creates the provider for a publish config
+func NewProvider(cfg *config.PublishConfig, options *ProviderOptions) (Provider, error) {
+	if options == nil {
+		options = &ProviderOptions{}
+	}
+
+	factory, ok := providerFactories[cfg.Provider]
+	if !ok {
+		return nil, fmt.Errorf("no provider %s", cfg.Provider)
+	}
+
+	return factory(cfg, options)
+}
+
+// FindAssets returns the files matching the glob patterns, an error is
+// returned if a pattern matches no file
+func FindAssets(patterns []string) ([]string, error) {
+	filenames := []string{}
+
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid asset pattern %s: %s", pattern, err)
+		}
+
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no asset found for %s", pattern)
+		}
+
+		filenames = append(filenames, matches...)
+	}
+
+	return filenames, nil
+}
+
+// Publisher creates or updates releases using a provider
+type Publisher struct {
+	provider Provider
+	logger   semver.Logger
+}
+
+// Publish creates the release or updates the existing release of the tag and
+// uploads the asset files, existing assets with the same name are replaced
+func (p *Publisher) Publish(release *Release, assetFilenames []string) (*Release, error) {
+	existingRelease, err := p.provider.GetRelease(release.Tag)
+	if err != nil && !errors.Is(err, ErrReleaseNotFound) {
+		return nil, fmt.Errorf("error loading release %s: %s", release.Tag, err)
+	}
+
+	var publishedRelease *Release
+
+	if existingRelease == nil {
+		p.logger.Infof("Creating release %s", release.Tag)
+
+		publishedRelease, err = p.provider.CreateRelease(release)
+		if err != nil {
+			return nil, fmt.Errorf("error creating release %s: %s", release.Tag, err)
+		}
+	} else {
+		p.logger.Infof("Updating release %s (%s)", release.Tag, existingRelease.ID)
+
+		updatedRelease := *release
+		updatedRelease.ID = existingRelease.ID
+
+		publishedRelease, err = p.provider.UpdateRelease(&updatedRelease)
+		if err != nil {
+			return nil, fmt.Errorf("error updating release %s: %s", release.Tag, err)
+		}
+	}
+
+	for _, filename := range assetFilenames {
+		name := filepath.Base(filename)
+
+		content, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return nil, fmt.Errorf("error reading asset %s: %s", filename, err)
+		}
+
+		if asset := publishedRelease.GetAsset(name); asset != nil {
+			p.logger.Debugf("Replacing asset %s (%s)", name, asset.ID)
+
+			err = p.provider.DeleteAsset(publishedRelease, asset)
+			if err != nil {
+				return nil, fmt.Errorf("error deleting asset %s: %s", name, err)
+			}
+		}
+
+		p.logger.Debugf("Uploading asset %s (%d bytes)", name, len(content))
+
+		err = p.provider.UploadAsset(publishedRelease, name, content)
+		if err != nil {
+			return nil, fmt.Errorf("error uploading asset %s: %s", name, err)
+		}
+	}
+
+	return publishedRelease, nil
+}
+
+// NewPublisher creates a new publisher for the provider
+func NewPublisher(provider Provider) *Publisher {
+	return &Publisher{
+		provider: provider,
+		logger:   semver.GetDefaultLogger(),
+	}
+}
+
+// SetLogger sets the logger receiving the diagnostics of the publisher
+func (p *Publisher) SetLogger(logger semver.Logger) {
+	p.logger = semver.GetLogger(logger)
+}
@@ -0,0 +1,255 @@
+package publish
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/indece-official/semantic-version/pkg/semver/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// testServer records all requests and answers them with the handler
+type testServer struct {
+	*httptest.Server
+	requests []string
+}
+
+func newTestServer(t *testing.T, handler func(w http.ResponseWriter, r *http.Request, body []byte)) *testServer {
+	s := &testServer{}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		s.requests = append(s.requests, fmt.Sprintf("%s %s", r.Method, r.URL.RequestURI()))
+
+		handler(w, r, body)
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func writeTestAsset(t *testing.T, name string, content string) string {
+	filename := filepath.Join(t.TempDir(), name)
+
+	err := ioutil.WriteFile(filename, []byte(content), 0644)
+	assert.NoError(t, err)
+
+	return filename
+}
+
+func TestPublishGitHubCreate(t *testing.T) {
+	var created map[string]interface{}
+	var uploaded string
+
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request, body []byte) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+		switch {
+		case r.Method == http.MethodGet:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"message":"Not Found"}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/repos/org/repo/releases":
+			assert.NoError(t, json.Unmarshal(body, &created))
+			fmt.Fprintf(w, `{"id":7,"tag_name":"v1.1.0-beta.0","html_url":"https://github.com/org/repo/releases/v1.1.0-beta.0","upload_url":"http://%s/upload/7{?name,label}","assets":[]}`, r.Host)
+		case r.Method == http.MethodPost && r.URL.Path == "/upload/7":
+			assert.Equal(t, "application/octet-stream", r.Header.Get("Content-Type"))
+			uploaded = string(body)
+			fmt.Fprintf(w, `{"id":1,"name":"app.tar.gz"}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	provider, err := NewProvider(&config.PublishConfig{
+		Provider:   config.PublishProviderGitHub,
+		URL:        server.URL,
+		Repository: "org/repo",
+	}, &ProviderOptions{Token: "secret"})
+	assert.NoError(t, err)
+
+	release, err := NewPublisher(provider).Publish(&Release{
+		Tag:        "v1.1.0-beta.0",
+		Name:       "v1.1.0-beta.0",
+		Body:       "## Features\n",
+		Prerelease: true,
+	}, []string{writeTestAsset(t, "app.tar.gz", "content")})
+	assert.NoError(t, err)
+	assert.Equal(t, "7", release.ID)
+	assert.Equal(t, "https://github.com/org/repo/releases/v1.1.0-beta.0", release.URL)
+
+	assert.Equal(t, []string{
+		"GET /repos/org/repo/releases/tags/v1.1.0-beta.0",
+		"POST /repos/org/repo/releases",
+		"POST /upload/7?name=app.tar.gz",
+	}, server.requests)
+	assert.Equal(t, "v1.1.0-beta.0", created["tag_name"])
+	assert.Equal(t, "## Features\n", created["body"])
+	assert.Equal(t, true, created["prerelease"])
+	assert.Equal(t, "content", uploaded)
+}
+
+func TestPublishGitHubUpdate(t *testing.T) {
+	var updated map[string]interface{}
+
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request, body []byte) {
+		switch {
+		case r.Method == http.MethodGet:
+			fmt.Fprintf(w, `{"id":7,"tag_name":"v1.1.0","upload_url":"http://%s/upload/7{?name,label}","assets":[{"id":3,"name":"app.tar.gz"}]}`, r.Host)
+		case r.Method == http.MethodPatch:
+			assert.NoError(t, json.Unmarshal(body, &updated))
+			fmt.Fprintf(w, `{"id":7,"tag_name":"v1.1.0","upload_url":"http://%s/upload/7{?name,label}","assets":[{"id":3,"name":"app.tar.gz"}]}`, r.Host)
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPost:
+			fmt.Fprintf(w, `{"id":4,"name":"app.tar.gz"}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	provider, err := NewGitHubProvider(&config.PublishConfig{URL: server.URL, Repository: "org/repo"}, &ProviderOptions{})
+	assert.NoError(t, err)
+
+	_, err = NewPublisher(provider).Publish(&Release{
+		Tag:  "v1.1.0",
+		Name: "v1.1.0",
+		Body: "## Fixes\n",
+	}, []string{writeTestAsset(t, "app.tar.gz", "content")})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"GET /repos/org/repo/releases/tags/v1.1.0",
+		"PATCH /repos/org/repo/releases/7",
+		"DELETE /repos/org/repo/releases/assets/3",
+		"POST /upload/7?name=app.tar.gz",
+	}, server.requests)
+	assert.Equal(t, "## Fixes\n", updated["body"])
+	assert.Equal(t, false, updated["prerelease"])
+}
+
+func TestPublishGitHubError(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request, body []byte) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprintf(w, `{"message":"Bad credentials"}`)
+	})
+
+	provider, err := NewGitHubProvider(&config.PublishConfig{URL: server.URL, Repository: "org/repo"}, &ProviderOptions{})
+	assert.NoError(t, err)
+
+	_, err = NewPublisher(provider).Publish(&Release{Tag: "v1.0.0"}, []string{})
+	assert.EqualError(t, err, "error loading release v1.0.0: status 401: {\"message\":\"Bad credentials\"}")
+}
+
+func TestPublishGitLab(t *testing.T) {
+	var link map[string]interface{}
+
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request, body []byte) {
+		assert.Equal(t, "secret", r.Header.Get("PRIVATE-TOKEN"))
+
+		switch {
+		case r.Method == http.MethodGet:
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/releases"):
+			fmt.Fprintf(w, `{"tag_name":"v1.0.0","name":"v1.0.0","_links":{"self":"https://gitlab.com/org/repo/-/releases/v1.0.0"},"assets":{"links":[]}}`)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/uploads"):
+			assert.True(t, strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data"))
+			assert.Contains(t, string(body), "content")
+			fmt.Fprintf(w, `{"url":"/uploads/abc/app.tar.gz","full_path":"/-/project/42/uploads/abc/app.tar.gz"}`)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/assets/links"):
+			assert.NoError(t, json.Unmarshal(body, &link))
+			fmt.Fprintf(w, `{"id":1}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	provider, err := NewProvider(&config.PublishConfig{
+		Provider:   config.PublishProviderGitLab,
+		URL:        server.URL + "/api/v4",
+		Repository: "org/repo",
+	}, &ProviderOptions{Token: "secret"})
+	assert.NoError(t, err)
+
+	release, err := NewPublisher(provider).Publish(&Release{
+		Tag:  "v1.0.0",
+		Name: "v1.0.0",
+		Body: "## Features\n",
+	}, []string{writeTestAsset(t, "app.tar.gz", "content")})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://gitlab.com/org/repo/-/releases/v1.0.0", release.URL)
+
+	assert.Equal(t, []string{
+		"GET /api/v4/projects/org%2Frepo/releases/v1.0.0",
+		"POST /api/v4/projects/org%2Frepo/releases",
+		"POST /api/v4/projects/org%2Frepo/uploads",
+		"POST /api/v4/projects/org%2Frepo/releases/v1.0.0/assets/links",
+	}, server.requests)
+	assert.Equal(t, "app.tar.gz", link["name"])
+	assert.Equal(t, server.URL+"/-/project/42/uploads/abc/app.tar.gz", link["url"])
+}
+
+func TestPublishGitea(t *testing.T) {
+	var updated map[string]interface{}
+
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request, body []byte) {
+		assert.Equal(t, "token secret", r.Header.Get("Authorization"))
+
+		switch {
+		case r.Method == http.MethodGet:
+			fmt.Fprintf(w, `{"id":5,"tag_name":"v1.0.0","assets":[{"id":9,"name":"app.tar.gz"}]}`)
+		case r.Method == http.MethodPatch:
+			assert.NoError(t, json.Unmarshal(body, &updated))
+			fmt.Fprintf(w, `{"id":5,"tag_name":"v1.0.0","html_url":"https://gitea.example.com/org/repo/releases/tag/v1.0.0","assets":[{"id":9,"name":"app.tar.gz"}]}`)
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPost:
+			assert.True(t, strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data"))
+			fmt.Fprintf(w, `{"id":10,"name":"app.tar.gz"}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	provider, err := NewProvider(&config.PublishConfig{
+		Provider:   config.PublishProviderGitea,
+		URL:        server.URL + "/api/v1",
+		Repository: "org/repo",
+	}, &ProviderOptions{Token: "secret"})
+	assert.NoError(t, err)
+
+	release, err := NewPublisher(provider).Publish(&Release{
+		Tag:        "v1.0.0",
+		Name:       "v1.0.0",
+		Prerelease: true,
+	}, []string{writeTestAsset(t, "app.tar.gz", "content")})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://gitea.example.com/org/repo/releases/tag/v1.0.0", release.URL)
+
+	assert.Equal(t, []string{
+		"GET /api/v1/repos/org/repo/releases/tags/v1.0.0",
+		"PATCH /api/v1/repos/org/repo/releases/5",
+		"DELETE /api/v1/repos/org/repo/releases/5/assets/9",
+		"POST /api/v1/repos/org/repo/releases/5/assets?name=app.tar.gz",
+	}, server.requests)
+	assert.Equal(t, true, updated["prerelease"])
+}
+
+func TestFindAssets(t *testing.T) {
+	filename := writeTestAsset(t, "app.tar.gz", "content")
+	dir := filepath.Dir(filename)
+
+	assets, err := FindAssets([]string{filepath.Join(dir, "*.tar.gz")})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filename}, assets)
+
+	_, err = FindAssets([]string{filepath.Join(dir, "*.zip")})
+	assert.Error(t, err)
+}
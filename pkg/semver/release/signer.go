@@ -0,0 +1,173 @@
+package release
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/indece-official/semantic-version/pkg/semver/config"
+	"golang.org/x/crypto/ssh"
+)
+
+// Signer creates an armored signature for a (tag) object
+type Signer interface {
+	Sign(message io.Reader) (string, error)
+}
+
+type gpgSigner struct {
+	entity *openpgp.Entity
+}
+
+func (s *gpgSigner) Sign(message io.Reader) (string, error) {
+	var buf bytes.Buffer
+
+	err := openpgp.ArmoredDetachSign(&buf, s.entity, message, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+func newGPGSigner(keyData []byte, passphrase string) (*gpgSigner, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(keyData))
+	if err != nil {
+		return nil, fmt.Errorf("can't read gpg key: %s", err)
+	}
+
+	for _, entity := range entities {
+		if entity.PrivateKey == nil {
+			continue
+		}
+
+		if entity.PrivateKey.Encrypted {
+			err = entity.DecryptPrivateKeys([]byte(passphrase))
+			if err != nil {
+				return nil, fmt.Errorf("can't decrypt gpg key: %s", err)
+			}
+		}
+
+		return &gpgSigner{
+			entity: entity,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no gpg private key found")
+}
+
+const (
+	sshSignatureNamespace     = "git"
+	sshSignatureHashAlgorithm = "sha512"
+)
+
+// sshSigner creates signatures in the SSHSIG format used by git
+// (see https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig)
+type sshSigner struct {
+	signer ssh.Signer
+}
+
+func (s *sshSigner) Sign(message io.Reader) (string, error) {
+	hash := sha512.New()
+	_, err := io.Copy(hash, message)
+	if err != nil {
+		return "", err
+	}
+
+	signedData := ssh.Marshal(struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          string
+	}{
+		Namespace:     sshSignatureNamespace,
+		HashAlgorithm: sshSignatureHashAlgorithm,
+		Hash:          string(hash.Sum(nil)),
+	})
+	signedData = append([]byte("SSHSIG"), signedData...)
+
+	var signature *ssh.Signature
+
+	algorithmSigner, ok := s.signer.(ssh.AlgorithmSigner)
+	if ok && s.signer.PublicKey().Type() == ssh.KeyAlgoRSA {
+		signature, err = algorithmSigner.SignWithAlgorithm(rand.Reader, signedData, ssh.KeyAlgoRSASHA512)
+	} else {
+		signature, err = s.signer.Sign(rand.Reader, signedData)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	blob := ssh.Marshal(struct {
+		Version       uint32
+		PublicKey     string
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     string
+	}{
+		Version:       1,
+		PublicKey:     string(s.signer.PublicKey().Marshal()),
+		Namespace:     sshSignatureNamespace,
+		HashAlgorithm: sshSignatureHashAlgorithm,
+		Signature:     string(ssh.Marshal(signature)),
+	})
+	blob = append([]byte("SSHSIG"), blob...)
+
+	encoded := base64.StdEncoding.EncodeToString(blob)
+	lines := []string{}
+	for len(encoded) > 70 {
+		lines = append(lines, encoded[:70])
+		encoded = encoded[70:]
+	}
+	lines = append(lines, encoded)
+
+	return "-----BEGIN SSH SIGNATURE-----\n" +
+		strings.Join(lines, "\n") +
+		"\n-----END SSH SIGNATURE-----\n", nil
+}
+
+func newSSHSigner(keyData []byte, passphrase string) (*sshSigner, error) {
+	var signer ssh.Signer
+	var err error
+
+	if passphrase != "" {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase(keyData, []byte(passphrase))
+	} else {
+		signer, err = ssh.ParsePrivateKey(keyData)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't read ssh key: %s", err)
+	}
+
+	return &sshSigner{
+		signer: signer,
+	}, nil
+}
+
+// NewSigner loads the signing key file for the sign method, returns nil if
+// the sign method is SignMethodNone
+func NewSigner(method config.SignMethod, keyFilename string, passphrase string) (Signer, error) {
+	if method == config.SignMethodNone {
+		return nil, nil
+	}
+
+	keyData, err := ioutil.ReadFile(keyFilename)
+	if err != nil {
+		return nil, fmt.Errorf("can't read signing key %s: %s", keyFilename, err)
+	}
+
+	switch method {
+	case config.SignMethodGPG:
+		return newGPGSigner(keyData, passphrase)
+	case config.SignMethodSSH:
+		return newSSHSigner(keyData, passphrase)
+	default:
+		return nil, fmt.Errorf("invalid sign method \"%s\"", method)
+	}
+}
@@ -0,0 +1,377 @@
+// Package release creates and publishes releases (tags) of a git repository.
+package release
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/indece-official/semantic-version/pkg/semver"
+	"github.com/indece-official/semantic-version/pkg/semver/config"
+)
+
+// ErrTagConflict is returned if a tag already exists on the remote
+var ErrTagConflict = errors.New("tag already exists on remote")
+
+// ErrTagExists is returned if a tag already exists locally on another commit
+var ErrTagExists = errors.New("tag already exists")
+
+// GenerateFunc generates a new version tag which is not contained in usedTags
+type GenerateFunc func(usedTags map[string]bool) (string, error)
+
+// TaggerOptions contains optional settings of a tagger
+type TaggerOptions struct {
+	// Remote overrides the remote from the tag config
+	Remote string
+	// Auth is used to authenticate against the remote
+	Auth transport.AuthMethod
+	// SigningKeyPassphrase is used to decrypt the signing key
+	SigningKeyPassphrase string
+	// Now returns the timestamp of created tags (defaults to time.Now)
+	Now func() time.Time
+	// Logger receives the diagnostics of the tagger, default is the logger
+	// set by semver.SetDefaultLogger
+	Logger semver.Logger
+}
+
+// Tagger creates version tags and pushes them to a remote
+type Tagger struct {
+	repo    *git.Repository
+	cfg     *config.TagConfig
+	options *TaggerOptions
+	logger  semver.Logger
+}
+
+func (t *Tagger) getRemote() string {
+	if t.options.Remote != "" {
+		return t.options.Remote
+	}
+
+	return t.cfg.Remote
+}
+
+func (t *Tagger) getTagger() (*object.Signature, error) {
+	name := t.cfg.TaggerName
+	email := t.cfg.TaggerEmail
+
+	if name == "" || email == "" {
+		repoConfig, err := t.repo.ConfigScoped(gitconfig.GlobalScope)
+		if err != nil {
+			return nil, fmt.Errorf("can't load git config: %s", err)
+		}
+
+		if name == "" {
+			name = repoConfig.User.Name
+		}
+
+		if email == "" {
+			email = repoConfig.User.Email
+		}
+	}
+
+	if name == "" {
+		name = "semantic-version"
+	}
+
+	return &object.Signature{
+		Name:  name,
+		Email: email,
+		When:  t.options.Now(),
+	}, nil
+}
+
+// getTagTarget returns the hash of the commit a local tag points to,
+// plumbing.ZeroHash if the tag doesn't exist
+func (t *Tagger) getTagTarget(name string) (plumbing.Hash, error) {
+	ref, err := t.repo.Tag(name)
+	if err != nil {
+		if errors.Is(err, git.ErrTagNotFound) {
+			return plumbing.ZeroHash, nil
+		}
+
+		return plumbing.ZeroHash, fmt.Errorf("can't load tag %s: %s", name, err)
+	}
+
+	tagObject, err := t.repo.TagObject(ref.Hash())
+	if err != nil {
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			// Lightweight tag
+			return ref.Hash(), nil
+		}
+
+		return plumbing.ZeroHash, fmt.Errorf("can't load tag object %s: %s", name, err)
+	}
+
+	return tagObject.Target, nil
+}
+
+func (t *Tagger) createAnnotatedTag(name string, target plumbing.Hash, message string) error {
+	tagger, err := t.getTagger()
+	if err != nil {
+		return err
+	}
+
+	if !strings.HasSuffix(message, "\n") {
+		message += "\n"
+	}
+
+	tag := &object.Tag{
+		Name:       name,
+		Tagger:     *tagger,
+		Message:    message,
+		TargetType: plumbing.CommitObject,
+		Target:     target,
+	}
+
+	signer, err := NewSigner(t.cfg.Sign, t.cfg.SigningKey, t.options.SigningKeyPassphrase)
+	if err != nil {
+		return err
+	}
+
+	if signer != nil {
+		unsignedObj := t.repo.Storer.NewEncodedObject()
+		err = tag.EncodeWithoutSignature(unsignedObj)
+		if err != nil {
+			return fmt.Errorf("can't encode tag: %s", err)
+		}
+
+		reader, err := unsignedObj.Reader()
+		if err != nil {
+			return fmt.Errorf("can't encode tag: %s", err)
+		}
+
+		unsignedData, err := ioutil.ReadAll(reader)
+		if err != nil {
+			return fmt.Errorf("can't encode tag: %s", err)
+		}
+
+		tag.PGPSignature, err = signer.Sign(bytes.NewReader(unsignedData))
+		if err != nil {
+			return fmt.Errorf("can't sign tag: %s", err)
+		}
+	}
+
+	obj := t.repo.Storer.NewEncodedObject()
+	err = tag.Encode(obj)
+	if err != nil {
+		return fmt.Errorf("can't encode tag: %s", err)
+	}
+
+	tagHash, err := t.repo.Storer.SetEncodedObject(obj)
+	if err != nil {
+		return fmt.Errorf("can't store tag: %s", err)
+	}
+
+	return t.repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewTagReferenceName(name), tagHash))
+}
+
+// CreateTag creates the tag on the target commit, returns false if the tag
+// already exists on the target commit
+//
+// ErrTagExists is returned if the tag exists on another commit.
+func (t *Tagger) CreateTag(name string, target plumbing.Hash, message string) (bool, error) {
+	existingTarget, err := t.getTagTarget(name)
+	if err != nil {
+		return false, err
+	}
+
+	if existingTarget == target {
+		t.logger.Debugf("Tag %s already exists on %s", name, target.String())
+
+		return false, nil
+	}
+
+	if !existingTarget.IsZero() {
+		return false, fmt.Errorf("%w: %s points to %s", ErrTagExists, name, existingTarget.String())
+	}
+
+	if t.cfg.Lightweight {
+		err = t.repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewTagReferenceName(name), target))
+	} else {
+		err = t.createAnnotatedTag(name, target, message)
+	}
+	if err != nil {
+		return false, fmt.Errorf("can't create tag %s: %s", name, err)
+	}
+
+	t.logger.Debugf("Created tag %s on %s", name, target.String())
+
+	return true, nil
+}
+
+// DeleteTag deletes a local tag
+func (t *Tagger) DeleteTag(name string) error {
+	return t.repo.DeleteTag(name)
+}
+
+// ListRemoteTags returns the names and hashes of all tags on the remote
+func (t *Tagger) ListRemoteTags() (map[string]plumbing.Hash, error) {
+	remote, err := t.repo.Remote(t.getRemote())
+	if err != nil {
+		return nil, fmt.Errorf("can't load remote %s: %s", t.getRemote(), err)
+	}
+
+	refs, err := remote.List(&git.ListOptions{
+		Auth: t.options.Auth,
+	})
+	if err != nil {
+		if errors.Is(err, transport.ErrEmptyRemoteRepository) {
+			return map[string]plumbing.Hash{}, nil
+		}
+
+		return nil, fmt.Errorf("can't list tags of remote %s: %s", t.getRemote(), err)
+	}
+
+	tags := map[string]plumbing.Hash{}
+	for _, ref := range refs {
+		if ref.Name().IsTag() {
+			tags[ref.Name().Short()] = ref.Hash()
+		}
+	}
+
+	return tags, nil
+}
+
+func isPushConflict(err error) bool {
+	if errors.Is(err, git.ErrForceNeeded) {
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+
+	return strings.Contains(msg, "already exists") ||
+		strings.Contains(msg, "rejected") ||
+		strings.Contains(msg, "non-fast-forward")
+}
+
+// PushTag pushes a local tag to the remote
+//
+// ErrTagConflict is returned if the tag exists on the remote with another value.
+func (t *Tagger) PushTag(name string) error {
+	refName := plumbing.NewTagReferenceName(name)
+
+	err := t.repo.Push(&git.PushOptions{
+		RemoteName: t.getRemote(),
+		RefSpecs:   []gitconfig.RefSpec{gitconfig.RefSpec(fmt.Sprintf("%s:%s", refName, refName))},
+		Auth:       t.options.Auth,
+	})
+	if err != nil {
+		if errors.Is(err, git.NoErrAlreadyUpToDate) {
+			return nil
+		}
+
+		if isPushConflict(err) {
+			return fmt.Errorf("%w: %s (%s)", ErrTagConflict, name, err)
+		}
+
+		return fmt.Errorf("can't push tag %s: %s", name, err)
+	}
+
+	t.logger.Debugf("Pushed tag %s to %s", name, t.getRemote())
+
+	return nil
+}
+
+// Release creates the tag name on the target commit and pushes it to the
+// remote (if configured)
+//
+// If the tag already exists on the remote, a new tag name is generated using
+// regenerate and the release is retried up to MaxRetries times. The name of
+// the created tag is returned.
+func (t *Tagger) Release(name string, target plumbing.Hash, message string, regenerate GenerateFunc) (string, error) {
+	var err error
+
+	push := t.getRemote() != ""
+	usedTags := map[string]bool{}
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			if attempt > t.cfg.MaxRetries {
+				return "", fmt.Errorf("%w: giving up after %d retries", ErrTagConflict, t.cfg.MaxRetries)
+			}
+
+			name, err = regenerate(usedTags)
+			if err != nil {
+				return "", err
+			}
+
+			t.logger.Debugf("Retrying with tag %s", name)
+		}
+
+		if push {
+			remoteTags, err := t.ListRemoteTags()
+			if err != nil {
+				return "", err
+			}
+
+			for tagName := range remoteTags {
+				usedTags[tagName] = true
+			}
+
+			remoteHash, exists := remoteTags[name]
+			if exists {
+				localRef, err := t.repo.Tag(name)
+				if err != nil || localRef.Hash() != remoteHash {
+					t.logger.Debugf("Tag %s already exists on remote", name)
+
+					continue
+				}
+			}
+		}
+
+		created, err := t.CreateTag(name, target, message)
+		if err != nil {
+			return "", err
+		}
+
+		if !push {
+			return name, nil
+		}
+
+		err = t.PushTag(name)
+		if err == nil {
+			return name, nil
+		}
+
+		if !errors.Is(err, ErrTagConflict) {
+			return "", err
+		}
+
+		t.logger.Debugf("Pushing tag %s failed: %s", name, err)
+
+		if created {
+			err = t.DeleteTag(name)
+			if err != nil {
+				return "", fmt.Errorf("can't delete tag %s: %s", name, err)
+			}
+		}
+
+		usedTags[name] = true
+	}
+}
+
+// NewTagger creates a new tagger for the repository
+func NewTagger(repo *git.Repository, cfg *config.TagConfig, options *TaggerOptions) *Tagger {
+	if options == nil {
+		options = &TaggerOptions{}
+	}
+
+	if options.Now == nil {
+		options.Now = time.Now
+	}
+
+	return &Tagger{
+		repo:    repo,
+		cfg:     cfg,
+		options: options,
+		logger:  semver.GetLogger(options.Logger),
+	}
+}
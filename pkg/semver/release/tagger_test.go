@@ -0,0 +1,191 @@
+package release
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	"github.com/go-git/go-git/v5/plumbing/transport/server"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/indece-official/semantic-version/pkg/semver/config"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+// newTestRepoWithRemote creates an in-memory repository with one commit and
+// an in-memory remote 'origin'
+func newTestRepoWithRemote(t *testing.T) (*git.Repository, *memory.Storage, plumbing.Hash) {
+	remoteStorage := memory.NewStorage()
+	remoteURL := fmt.Sprintf("mem://%s", strings.ReplaceAll(t.Name(), "/", "_"))
+
+	client.InstallProtocol("mem", server.NewClient(server.MapLoader{
+		remoteURL: remoteStorage,
+	}))
+
+	_, err := git.Init(remoteStorage, nil)
+	assert.NoError(t, err)
+
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	assert.NoError(t, err)
+
+	_, err = repo.CreateRemote(&gitconfig.RemoteConfig{
+		Name: "origin",
+		URLs: []string{remoteURL},
+	})
+	assert.NoError(t, err)
+
+	worktree, err := repo.Worktree()
+	assert.NoError(t, err)
+
+	hash, err := worktree.Commit("Initial commit", &git.CommitOptions{
+		AllowEmptyCommits: true,
+		Author: &object.Signature{
+			Name:  "Test",
+			Email: "test@example.com",
+			When:  time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	})
+	assert.NoError(t, err)
+
+	return repo, remoteStorage, hash
+}
+
+func TestTaggerRelease(t *testing.T) {
+	repo, remoteStorage, hash := newTestRepoWithRemote(t)
+
+	tagger := NewTagger(repo, &config.TagConfig{
+		TaggerName:  "Release Bot",
+		TaggerEmail: "bot@example.com",
+		Remote:      "origin",
+		MaxRetries:  3,
+	}, nil)
+
+	name, err := tagger.Release("v1.0.0", hash, "Some changelog", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.0.0", name)
+
+	ref, err := repo.Tag("v1.0.0")
+	assert.NoError(t, err)
+
+	tagObject, err := repo.TagObject(ref.Hash())
+	assert.NoError(t, err)
+	assert.Equal(t, "Some changelog\n", tagObject.Message)
+	assert.Equal(t, "Release Bot", tagObject.Tagger.Name)
+	assert.Equal(t, hash, tagObject.Target)
+
+	remoteRef, err := remoteStorage.Reference(plumbing.NewTagReferenceName("v1.0.0"))
+	assert.NoError(t, err)
+	assert.Equal(t, ref.Hash(), remoteRef.Hash())
+
+	// Releasing the same tag again is a no-op
+	name, err = tagger.Release("v1.0.0", hash, "Some changelog", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.0.0", name)
+}
+
+func TestTaggerReleaseConflict(t *testing.T) {
+	repo, remoteStorage, hash := newTestRepoWithRemote(t)
+
+	err := remoteStorage.SetReference(plumbing.NewHashReference(plumbing.NewTagReferenceName("v1.0.0-beta.0"), hash))
+	assert.NoError(t, err)
+
+	tagger := NewTagger(repo, &config.TagConfig{
+		Lightweight: true,
+		Remote:      "origin",
+		MaxRetries:  3,
+	}, nil)
+
+	build := 0
+	name, err := tagger.Release("v1.0.0-beta.0", hash, "", func(usedTags map[string]bool) (string, error) {
+		for usedTags[fmt.Sprintf("v1.0.0-beta.%d", build)] {
+			build++
+		}
+
+		return fmt.Sprintf("v1.0.0-beta.%d", build), nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.0.0-beta.1", name)
+
+	remoteRef, err := remoteStorage.Reference(plumbing.NewTagReferenceName("v1.0.0-beta.1"))
+	assert.NoError(t, err)
+	assert.Equal(t, hash, remoteRef.Hash())
+}
+
+func TestTaggerReleaseConflictMaxRetries(t *testing.T) {
+	repo, remoteStorage, hash := newTestRepoWithRemote(t)
+
+	err := remoteStorage.SetReference(plumbing.NewHashReference(plumbing.NewTagReferenceName("v1.0.0"), hash))
+	assert.NoError(t, err)
+
+	tagger := NewTagger(repo, &config.TagConfig{
+		Remote:     "origin",
+		MaxRetries: 2,
+	}, nil)
+
+	_, err = tagger.Release("v1.0.0", hash, "", func(usedTags map[string]bool) (string, error) {
+		return "v1.0.0", nil
+	})
+	assert.ErrorIs(t, err, ErrTagConflict)
+}
+
+func TestSSHSignerSign(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	sshPrivateKey, err := ssh.NewSignerFromKey(privateKey)
+	assert.NoError(t, err)
+
+	signer := &sshSigner{
+		signer: sshPrivateKey,
+	}
+
+	armored, err := signer.Sign(strings.NewReader("object 123\n"))
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(armored, "-----BEGIN SSH SIGNATURE-----\n"))
+	assert.True(t, strings.HasSuffix(armored, "\n-----END SSH SIGNATURE-----\n"))
+
+	encoded := strings.TrimPrefix(armored, "-----BEGIN SSH SIGNATURE-----\n")
+	encoded = strings.TrimSuffix(encoded, "\n-----END SSH SIGNATURE-----\n")
+	blob, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(encoded, "\n", ""))
+	assert.NoError(t, err)
+	assert.Equal(t, "SSHSIG", string(blob[:6]))
+
+	sig := struct {
+		Version       uint32
+		PublicKey     string
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     string
+	}{}
+	err = ssh.Unmarshal(blob[6:], &sig)
+	assert.NoError(t, err)
+	assert.Equal(t, "git", sig.Namespace)
+	assert.Equal(t, "sha512", sig.HashAlgorithm)
+
+	signature := &ssh.Signature{}
+	err = ssh.Unmarshal([]byte(sig.Signature), signature)
+	assert.NoError(t, err)
+
+	hash := sha512.Sum512([]byte("object 123\n"))
+	signedData := append([]byte("SSHSIG"), ssh.Marshal(struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          string
+	}{"git", "", "sha512", string(hash[:])})...)
+
+	err = sshPrivateKey.PublicKey().Verify(signedData, signature)
+	assert.NoError(t, err)
+}
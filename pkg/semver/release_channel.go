@@ -0,0 +1,120 @@
+package semver
+
+// ReleaseChannel is the release channel of a branch (e.g. BETA)
+type ReleaseChannel string
+
+const (
+	ReleaseChannelNone  ReleaseChannel = ""
+	ReleaseChannelAlpha ReleaseChannel = "ALPHA"
+	ReleaseChannelBeta  ReleaseChannel = "BETA"
+	ReleaseChannelGamma ReleaseChannel = "GAMMA"
+	ReleaseChannelFinal ReleaseChannel = "FINAL"
+)
+
+// ReleaseChannelDefinition defines the ordering of a release channel and if
+// its versions are releases
+type ReleaseChannelDefinition struct {
+	Name ReleaseChannel `yaml:"name"`
+	// Priority orders the release channels (higher is more stable), channels
+	// with at least the priority of FINAL are final releases
+	Priority int `yaml:"priority"`
+	// Release specifies if versions of the release channel are releases
+	// (default true, e.g. false for nightly builds)
+	Release *bool `yaml:"release,omitempty"`
+}
+
+// IsRelease returns true if versions of the release channel are releases
+func (d *ReleaseChannelDefinition) IsRelease() bool {
+	return d.Release == nil || *d.Release
+}
+
+// DefaultReleaseChannels are the release channels used if none are configured
+var DefaultReleaseChannels = []*ReleaseChannelDefinition{
+	{Name: ReleaseChannelAlpha, Priority: 1},
+	{Name: ReleaseChannelBeta, Priority: 2},
+	{Name: ReleaseChannelGamma, Priority: 3},
+	{Name: ReleaseChannelFinal, Priority: 4},
+}
+
+// ReleaseChannels is a set of defined release channels resolving their
+// ordering and release status
+type ReleaseChannels struct {
+	definitions map[ReleaseChannel]*ReleaseChannelDefinition
+}
+
+// defaultReleaseChannels is used for a nil *ReleaseChannels
+var defaultReleaseChannels = NewReleaseChannels(DefaultReleaseChannels)
+
+// NewReleaseChannels creates a set of the release channel definitions (e.g.
+// the release channels from the config), ReleaseChannelNone is always defined
+func NewReleaseChannels(definitions []*ReleaseChannelDefinition) *ReleaseChannels {
+	releaseChannels := &ReleaseChannels{
+		definitions: map[ReleaseChannel]*ReleaseChannelDefinition{},
+	}
+
+	for _, definition := range definitions {
+		releaseChannels.definitions[definition.Name] = definition
+	}
+
+	return releaseChannels
+}
+
+// get returns the definitions, the default release channels if r is nil
+func (r *ReleaseChannels) get() map[ReleaseChannel]*ReleaseChannelDefinition {
+	if r == nil {
+		return defaultReleaseChannels.definitions
+	}
+
+	return r.definitions
+}
+
+// IsDefined returns true if the release channel is ReleaseChannelNone or
+// a defined release channel
+func (r *ReleaseChannels) IsDefined(releaseChannel ReleaseChannel) bool {
+	_, exists := r.get()[releaseChannel]
+
+	return releaseChannel == ReleaseChannelNone || exists
+}
+
+// IsRelease returns true if versions of the release channel are releases
+func (r *ReleaseChannels) IsRelease(releaseChannel ReleaseChannel) bool {
+	definition, exists := r.get()[releaseChannel]
+
+	return releaseChannel != ReleaseChannelNone && exists && definition.IsRelease()
+}
+
+// IsFinal returns true if the release channel has at least the priority of
+// FINAL
+func (r *ReleaseChannels) IsFinal(releaseChannel ReleaseChannel) bool {
+	return releaseChannel != ReleaseChannelNone && r.GetPrio(releaseChannel) >= r.GetPrio(ReleaseChannelFinal)
+}
+
+// GetPrio returns the priority of the release channel (higher is more stable),
+// 0 for ReleaseChannelNone and undefined release channels
+func (r *ReleaseChannels) GetPrio(releaseChannel ReleaseChannel) int {
+	definition, exists := r.get()[releaseChannel]
+	if releaseChannel == ReleaseChannelNone || !exists {
+		return 0
+	}
+
+	return definition.Priority
+}
+
+// GetLowestRelease returns the release channel with the lowest priority whose
+// versions are releases
+func (r *ReleaseChannels) GetLowestRelease() ReleaseChannel {
+	lowest := ReleaseChannelFinal
+
+	for name, definition := range r.get() {
+		if !definition.IsRelease() {
+			continue
+		}
+
+		if definition.Priority < r.GetPrio(lowest) ||
+			(definition.Priority == r.GetPrio(lowest) && name < lowest) {
+			lowest = name
+		}
+	}
+
+	return lowest
+}
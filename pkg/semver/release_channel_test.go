@@ -0,0 +1,57 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReleaseChannelEquals(t *testing.T) {
+	a := ReleaseChannelAlpha
+	b := ReleaseChannelAlpha
+	c := ReleaseChannelFinal
+
+	assert.True(t, a == b)
+	assert.True(t, b == a)
+	assert.False(t, a == c)
+	assert.False(t, b == c)
+}
+
+func TestReleaseChannelDefinitions(t *testing.T) {
+	var defaults *ReleaseChannels
+
+	assert.Equal(t, 3, defaults.GetPrio(ReleaseChannelGamma))
+	assert.True(t, defaults.IsRelease(ReleaseChannelGamma))
+	assert.False(t, defaults.IsRelease(ReleaseChannelNone))
+	assert.True(t, defaults.IsDefined(ReleaseChannelNone))
+	assert.False(t, defaults.IsDefined("RC"))
+	assert.True(t, defaults.IsFinal(ReleaseChannelFinal))
+	assert.False(t, defaults.IsFinal(ReleaseChannelGamma))
+
+	release := false
+	releaseChannels := NewReleaseChannels([]*ReleaseChannelDefinition{
+		{Name: "NIGHTLY", Priority: 1, Release: &release},
+		{Name: "RC", Priority: 5},
+		{Name: ReleaseChannelFinal, Priority: 10},
+	})
+
+	assert.False(t, releaseChannels.IsDefined(ReleaseChannelGamma))
+	assert.Equal(t, 0, releaseChannels.GetPrio(ReleaseChannelGamma))
+	assert.Equal(t, 5, releaseChannels.GetPrio("RC"))
+	assert.True(t, releaseChannels.IsRelease("RC"))
+	assert.False(t, releaseChannels.IsFinal("RC"))
+	assert.True(t, releaseChannels.IsDefined("NIGHTLY"))
+	assert.False(t, releaseChannels.IsRelease("NIGHTLY"))
+	assert.Equal(t, ReleaseChannel("RC"), releaseChannels.GetLowestRelease())
+	assert.Equal(t, ReleaseChannelAlpha, defaults.GetLowestRelease())
+
+	// The defaults are not affected by other sets
+	assert.True(t, defaults.IsDefined(ReleaseChannelGamma))
+
+	scheme := GetVersionScheme(VersionSchemeSemver)
+	assert.Equal(t, "-rc", scheme.GetQualifier("RC", releaseChannels))
+	assert.Equal(t, "-nightly", scheme.GetQualifier("NIGHTLY", releaseChannels))
+	assert.Equal(t, ReleaseChannel("RC"), scheme.ParseQualifier("-rc.2", releaseChannels))
+	assert.Equal(t, ReleaseChannel("NIGHTLY"), scheme.ParseQualifier("-nightly", releaseChannels))
+	assert.Equal(t, ReleaseChannelNone, scheme.ParseQualifier("-nightly", nil))
+}
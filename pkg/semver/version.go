@@ -0,0 +1,181 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// expVersion matches a semantic version (see https://semver.org/spec/v2.0.0.html)
+var expVersion = regexp.MustCompile(`^(?P<major>0|[1-9]\d*)\.(?P<minor>0|[1-9]\d*)\.(?P<patch>0|[1-9]\d*)` +
+	`(?:-(?P<prerelease>(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+(?P<metadata>[0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// expVersionPrefix matches the prefix of a tag name before the version (e.g. 'v')
+var expVersionPrefix = regexp.MustCompile(`^[^0-9]*`)
+
+// Version is a semantic version following semver 2.0.0
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+	// Prerelease contains the dot-separated prerelease identifiers (e.g. ['beta', '1'])
+	Prerelease []string
+	// Metadata contains the dot-separated build metadata identifiers, it is
+	// ignored when comparing versions
+	Metadata []string
+}
+
+// IsPrerelease returns true if the version has prerelease identifiers
+func (v *Version) IsPrerelease() bool {
+	return len(v.Prerelease) > 0
+}
+
+// String formats the version (e.g. '1.2.3-beta.1+build.5')
+func (v *Version) String() string {
+	str := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+
+	if len(v.Prerelease) > 0 {
+		str += "-" + strings.Join(v.Prerelease, ".")
+	}
+
+	if len(v.Metadata) > 0 {
+		str += "+" + strings.Join(v.Metadata, ".")
+	}
+
+	return str
+}
+
+func compareInts(a int, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func isNumericIdentifier(identifier string) bool {
+	for _, c := range identifier {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return identifier != ""
+}
+
+// comparePrereleaseIdentifiers compares numeric identifiers numerically and
+// alphanumeric identifiers lexically, numeric identifiers have a lower
+// precedence than alphanumeric identifiers
+func comparePrereleaseIdentifiers(a string, b string) int {
+	numericA := isNumericIdentifier(a)
+	numericB := isNumericIdentifier(b)
+
+	switch {
+	case numericA && numericB:
+		// Numeric identifiers have no leading zeros, so the longer one is greater
+		if len(a) != len(b) {
+			return compareInts(len(a), len(b))
+		}
+
+		return strings.Compare(a, b)
+	case numericA:
+		return -1
+	case numericB:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// Compare compares the precedence of the versions, returns -1 if v is lower
+// than b, 1 if v is greater than b and 0 if both have the same precedence
+func (v *Version) Compare(b *Version) int {
+	if c := compareInts(v.Major, b.Major); c != 0 {
+		return c
+	}
+
+	if c := compareInts(v.Minor, b.Minor); c != 0 {
+		return c
+	}
+
+	if c := compareInts(v.Patch, b.Patch); c != 0 {
+		return c
+	}
+
+	// A version without prerelease has a higher precedence
+	switch {
+	case !v.IsPrerelease() && !b.IsPrerelease():
+		return 0
+	case !v.IsPrerelease():
+		return 1
+	case !b.IsPrerelease():
+		return -1
+	}
+
+	for i := 0; i < len(v.Prerelease) && i < len(b.Prerelease); i++ {
+		if c := comparePrereleaseIdentifiers(v.Prerelease[i], b.Prerelease[i]); c != 0 {
+			return c
+		}
+	}
+
+	return compareInts(len(v.Prerelease), len(b.Prerelease))
+}
+
+// ParseVersion parses a semantic version (e.g. '1.2.3-beta.1+build.5')
+func ParseVersion(str string) (*Version, error) {
+	match := expVersion.FindStringSubmatch(str)
+	if match == nil {
+		return nil, fmt.Errorf("invalid semantic version \"%s\"", str)
+	}
+
+	version := &Version{
+		Prerelease: []string{},
+		Metadata:   []string{},
+	}
+
+	var err error
+
+	version.Major, err = strconv.Atoi(match[expVersion.SubexpIndex("major")])
+	if err != nil {
+		return nil, fmt.Errorf("invalid major version in \"%s\": %s", str, err)
+	}
+
+	version.Minor, err = strconv.Atoi(match[expVersion.SubexpIndex("minor")])
+	if err != nil {
+		return nil, fmt.Errorf("invalid minor version in \"%s\": %s", str, err)
+	}
+
+	version.Patch, err = strconv.Atoi(match[expVersion.SubexpIndex("patch")])
+	if err != nil {
+		return nil, fmt.Errorf("invalid patch version in \"%s\": %s", str, err)
+	}
+
+	prerelease := match[expVersion.SubexpIndex("prerelease")]
+	if prerelease != "" {
+		version.Prerelease = strings.Split(prerelease, ".")
+	}
+
+	metadata := match[expVersion.SubexpIndex("metadata")]
+	if metadata != "" {
+		version.Metadata = strings.Split(metadata, ".")
+	}
+
+	return version, nil
+}
+
+// ParseTagVersion parses the semantic version of a tag name after an optional
+// prefix (e.g. 'v1.2.3' or 'api/v1.2.3'), returns nil if the tag name doesn't
+// contain a semantic version
+func ParseTagVersion(tagName string) *Version {
+	version, err := ParseVersion(expVersionPrefix.ReplaceAllString(tagName, ""))
+	if err != nil {
+		return nil
+	}
+
+	return version
+}
@@ -0,0 +1,152 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// VersionIncrementLevel specifies which component of a version is incremented
+type VersionIncrementLevel int
+
+const (
+	VersionIncrementLevelNone  VersionIncrementLevel = -1
+	VersionIncrementLevelBuild VersionIncrementLevel = 0
+	VersionIncrementLevelPatch VersionIncrementLevel = 1
+	VersionIncrementLevelMinor VersionIncrementLevel = 2
+	VersionIncrementLevelMajor VersionIncrementLevel = 3
+)
+
+func (l VersionIncrementLevel) String() string {
+	switch l {
+	case VersionIncrementLevelNone:
+		return "NONE"
+	case VersionIncrementLevelBuild:
+		return "BUILD"
+	case VersionIncrementLevelPatch:
+		return "PATCH"
+	case VersionIncrementLevelMinor:
+		return "MINOR"
+	case VersionIncrementLevelMajor:
+		return "MAJOR"
+	default:
+		return fmt.Sprintf("%d", int(l))
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (l VersionIncrementLevel) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (l *VersionIncrementLevel) UnmarshalText(text []byte) error {
+	level, err := ParseVersionIncrementLevel(string(text))
+	if err != nil {
+		return err
+	}
+
+	*l = level
+
+	return nil
+}
+
+// ParseVersionIncrementLevel parses a level name (e.g. 'MINOR')
+func ParseVersionIncrementLevel(str string) (VersionIncrementLevel, error) {
+	switch strings.ToUpper(str) {
+	case "NONE":
+		return VersionIncrementLevelNone, nil
+	case "BUILD":
+		return VersionIncrementLevelBuild, nil
+	case "PATCH":
+		return VersionIncrementLevelPatch, nil
+	case "MINOR":
+		return VersionIncrementLevelMinor, nil
+	case "MAJOR":
+		return VersionIncrementLevelMajor, nil
+	default:
+		return VersionIncrementLevelNone, fmt.Errorf("invalid version increment level \"%s\"", str)
+	}
+}
+
+// VersionIncrement collects the increments caused by changes and applies the
+// highest one to a version
+type VersionIncrement struct {
+	level VersionIncrementLevel
+}
+
+func (v *VersionIncrement) incrementTo(level VersionIncrementLevel) {
+	if v.level >= level {
+		return
+	}
+
+	v.level = level
+}
+
+func (v *VersionIncrement) IncrementMajor() {
+	v.incrementTo(VersionIncrementLevelMajor)
+}
+
+func (v *VersionIncrement) IncrementMinor() {
+	v.incrementTo(VersionIncrementLevelMinor)
+}
+
+func (v *VersionIncrement) IncrementPatch() {
+	v.incrementTo(VersionIncrementLevelPatch)
+}
+
+func (v *VersionIncrement) IncrementBuild() {
+	v.incrementTo(VersionIncrementLevelBuild)
+}
+
+func (v *VersionIncrement) Increment(level VersionIncrementLevel) {
+	v.incrementTo(level)
+}
+
+func (v *VersionIncrement) GetLevel() VersionIncrementLevel {
+	return v.level
+}
+
+// Limit lowers the collected level to maxLevel (e.g. for calendar versions
+// without {major} and {minor} a breaking change increments the micro number)
+func (v *VersionIncrement) Limit(maxLevel VersionIncrementLevel) {
+	if v.level <= maxLevel {
+		return
+	}
+
+	v.level = maxLevel
+}
+
+// Apply increments the version by the collected level
+func (v *VersionIncrement) Apply(versionInfo *VersionInfo) {
+	switch v.level {
+	case VersionIncrementLevelMajor:
+		versionInfo.Major++
+		versionInfo.Minor = 0
+		versionInfo.Patch = 0
+		versionInfo.Build = 0
+	case VersionIncrementLevelMinor:
+		versionInfo.Minor++
+		versionInfo.Patch = 0
+		versionInfo.Build = 0
+	case VersionIncrementLevelPatch:
+		versionInfo.Patch++
+		versionInfo.Build = 0
+	case VersionIncrementLevelBuild:
+		versionInfo.Build++
+	}
+}
+
+func NewVersionIncrement() *VersionIncrement {
+	return &VersionIncrement{
+		level: VersionIncrementLevelBuild,
+	}
+}
+
+// NewVersionIncrementNone creates a version increment without level for changes
+// not causing an increment (Apply doesn't change the version until a level is
+// collected)
+func NewVersionIncrementNone() *VersionIncrement {
+	return &VersionIncrement{
+		level: VersionIncrementLevelNone,
+	}
+}
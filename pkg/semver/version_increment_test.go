@@ -0,0 +1,58 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionIncrementApply(t *testing.T) {
+	info := &VersionInfo{
+		Major: 1,
+		Minor: 0,
+		Patch: 2,
+		Build: 0,
+	}
+
+	inc := NewVersionIncrement()
+	inc.IncrementBuild()
+	inc.IncrementMinor()
+	inc.Apply(info)
+
+	assert.Equal(t, 1, info.Major)
+	assert.Equal(t, 1, info.Minor)
+	assert.Equal(t, 0, info.Patch)
+	assert.Equal(t, 0, info.Build)
+}
+
+func TestVersionIncrementLimit(t *testing.T) {
+	inc := NewVersionIncrement()
+	inc.IncrementMajor()
+	inc.Limit(VersionIncrementLevelPatch)
+	assert.Equal(t, VersionIncrementLevelPatch, inc.GetLevel())
+
+	inc = NewVersionIncrement()
+	inc.IncrementBuild()
+	inc.Limit(VersionIncrementLevelPatch)
+	assert.Equal(t, VersionIncrementLevelBuild, inc.GetLevel())
+}
+
+func TestVersionIncrementNone(t *testing.T) {
+	info := &VersionInfo{
+		Major: 1,
+		Minor: 0,
+		Patch: 2,
+		Build: 3,
+	}
+
+	inc := NewVersionIncrementNone()
+	inc.Increment(VersionIncrementLevelNone)
+	inc.Apply(info)
+
+	assert.Equal(t, VersionIncrementLevelNone, inc.GetLevel())
+	assert.Equal(t, &VersionInfo{Major: 1, Minor: 0, Patch: 2, Build: 3}, info)
+
+	inc = NewVersionIncrement()
+	inc.Increment(VersionIncrementLevelNone)
+	assert.Equal(t, VersionIncrementLevelBuild, inc.GetLevel())
+}
@@ -0,0 +1,177 @@
+package semver
+
+import (
+	"fmt"
+	"time"
+)
+
+// VersionInfo contains all components of a version
+type VersionInfo struct {
+	Major          int
+	Minor          int
+	Patch          int
+	Build          int
+	Branch         string
+	Commit         string
+	ShortCommit    string
+	ReleaseChannel ReleaseChannel
+	// Year, Month, Week (ISO week) and Day are the date components of
+	// calendar versions, 0 if not used
+	Year  int
+	Month int
+	Week  int
+	Day   int
+	// Scheme is the version scheme of the pattern the version was parsed with,
+	// nil for semver
+	Scheme VersionScheme
+	// ReleaseChannels are the release channels of the pattern the version was
+	// parsed with, nil for the default release channels
+	ReleaseChannels *ReleaseChannels
+}
+
+// GetScheme returns the version scheme of the version
+func (v *VersionInfo) GetScheme() VersionScheme {
+	if v.Scheme == nil {
+		return GetVersionScheme(VersionSchemeSemver)
+	}
+
+	return v.Scheme
+}
+
+// SetDate sets the date components of calendar versions, the year is the
+// ISO week-numbering year if isoWeek is set (e.g. 2025 for 2024-12-30 in
+// week 1)
+func (v *VersionInfo) SetDate(date time.Time, isoWeek bool) {
+	year, week := date.ISOWeek()
+	if !isoWeek {
+		year = date.Year()
+	}
+
+	v.Year = year
+	v.Month = int(date.Month())
+	v.Week = week
+	v.Day = date.Day()
+}
+
+// compareDate compares the date components of the versions
+func (v *VersionInfo) compareDate(b *VersionInfo) int {
+	if c := compareInts(v.Year, b.Year); c != 0 {
+		return c
+	}
+
+	if c := compareInts(v.Month, b.Month); c != 0 {
+		return c
+	}
+
+	if c := compareInts(v.Week, b.Week); c != 0 {
+		return c
+	}
+
+	return compareInts(v.Day, b.Day)
+}
+
+// IsGreaterThan checks if the version is greater than version b
+//
+// Versions are compared by their date (calendar versions), components and the
+// priority of their release channel, use Tag.Compare for semver 2.0.0
+// precedence.
+func (v *VersionInfo) IsGreaterThan(b *VersionInfo) bool {
+	if c := v.compareDate(b); c != 0 {
+		return c > 0
+	}
+
+	if v.Major > b.Major {
+		return true
+	}
+
+	if v.Major == b.Major &&
+		v.Minor > b.Minor {
+		return true
+	}
+
+	if v.Major == b.Major &&
+		v.Minor == b.Minor &&
+		v.Patch > b.Patch {
+		return true
+	}
+
+	if v.Major == b.Major &&
+		v.Minor == b.Minor &&
+		v.Patch == b.Patch &&
+		v.ReleaseChannels.GetPrio(v.ReleaseChannel) > v.ReleaseChannels.GetPrio(b.ReleaseChannel) {
+		return true
+	}
+
+	if v.Major == b.Major &&
+		v.Minor == b.Minor &&
+		v.Patch == b.Patch &&
+		v.ReleaseChannels.GetPrio(v.ReleaseChannel) == v.ReleaseChannels.GetPrio(b.ReleaseChannel) &&
+		v.Build > b.Build {
+		return true
+	}
+
+	return false
+}
+
+func (v *VersionInfo) String() string {
+	return fmt.Sprintf("%d.%d.%d.%d-%s [%s]", v.Major, v.Minor, v.Patch, v.Build, v.Branch, v.ReleaseChannel)
+}
+
+// Tag is a git tag matching a version pattern
+type Tag struct {
+	Version *VersionInfo
+	Name    string
+	// Commit is the hash of the tagged commit
+	Commit string
+	// SchemeVersion is the version contained in the tag name parsed by the
+	// scheme of the version info, nil if the tag name doesn't contain a valid
+	// version of the scheme
+	SchemeVersion SchemeVersion
+	// SemVer is the semantic version contained in the tag name, nil if the
+	// tag name doesn't contain a valid semantic version or uses another scheme
+	SemVer *Version
+}
+
+// Compare compares the precedence of the tags, returns -1 if t is lower than
+// b, 1 if t is greater than b and 0 if both have the same precedence
+//
+// If both tag names contain a valid version of the same scheme, the ordering
+// of the scheme is used (e.g. semver 2.0.0 precedence), else the version infos
+// are compared (see VersionInfo.IsGreaterThan).
+func (t *Tag) Compare(b *Tag) int {
+	scheme := t.Version.GetScheme()
+
+	if t.SchemeVersion != nil && b.SchemeVersion != nil &&
+		scheme.GetName() == b.Version.GetScheme().GetName() {
+		if c := scheme.Compare(t.SchemeVersion, b.SchemeVersion); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case t.Version.IsGreaterThan(b.Version):
+		return 1
+	case b.Version.IsGreaterThan(t.Version):
+		return -1
+	default:
+		return 0
+	}
+}
+
+// NewTag creates a tag, the version is parsed from the tag name (after an
+// optional prefix) using the scheme of the version info
+func NewTag(name string, versionInfo *VersionInfo, commit string) *Tag {
+	tag := &Tag{
+		Name:    name,
+		Version: versionInfo,
+		Commit:  commit,
+	}
+
+	schemeVersion, err := versionInfo.GetScheme().Parse(expVersionPrefix.ReplaceAllString(name, ""))
+	if err == nil {
+		tag.SchemeVersion = schemeVersion
+		tag.SemVer, _ = schemeVersion.(*Version)
+	}
+
+	return tag
+}
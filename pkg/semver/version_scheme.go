@@ -0,0 +1,165 @@
+package semver
+
+import (
+	"strings"
+)
+
+// Names of the built-in version schemes
+const (
+	// VersionSchemeSemver orders versions following semver 2.0.0 (default)
+	VersionSchemeSemver = "SEMVER"
+	// VersionSchemeMaven orders versions like Maven's ComparableVersion and
+	// uses SNAPSHOT versions for non-final release channels
+	VersionSchemeMaven = "MAVEN"
+	// VersionSchemePEP440 orders versions following PEP 440 (Python)
+	VersionSchemePEP440 = "PEP440"
+	// VersionSchemeCalVer orders versions by any number of numeric
+	// components (e.g. 2024.03.1)
+	VersionSchemeCalVer = "CALVER"
+	// VersionSchemeNuGet orders versions like NuGet (up to four numeric
+	// components, case-insensitive prerelease labels)
+	VersionSchemeNuGet = "NUGET"
+	// VersionSchemeDebian orders versions like dpkg ('~' sorts before
+	// everything)
+	VersionSchemeDebian = "DEBIAN"
+)
+
+// SchemeVersion is a version parsed by a version scheme
+type SchemeVersion interface {
+	String() string
+}
+
+// VersionScheme controls the parsing, formatting and ordering of versions
+type VersionScheme interface {
+	// GetName returns the name of the scheme as used in the config
+	GetName() string
+	// Parse parses a version (tag name without prefix), an error is returned
+	// if the version is invalid in the scheme
+	Parse(version string) (SchemeVersion, error)
+	// Compare compares two versions parsed by the scheme, returns -1 if a is
+	// lower than b, 1 if a is greater than b and 0 if both are equal
+	Compare(a SchemeVersion, b SchemeVersion) int
+	// GetQualifier returns the qualifier of versions of the release channel
+	// (placeholder {qualifier}, e.g. '-beta'), empty for FINAL, releaseChannels
+	// are the defined release channels (default release channels if nil)
+	GetQualifier(releaseChannel ReleaseChannel, releaseChannels *ReleaseChannels) string
+	// GetQualifierPattern returns a regular expression matching all qualifiers
+	// including the empty qualifier
+	GetQualifierPattern() string
+	// ParseQualifier returns the release channel of a qualifier
+	ParseQualifier(qualifier string, releaseChannels *ReleaseChannels) ReleaseChannel
+}
+
+var versionSchemes = map[string]VersionScheme{
+	VersionSchemeSemver: &semverScheme{},
+	VersionSchemeMaven:  &mavenScheme{},
+	VersionSchemePEP440: &pep440Scheme{},
+	VersionSchemeCalVer: &numericScheme{name: VersionSchemeCalVer},
+	VersionSchemeNuGet:  &numericScheme{name: VersionSchemeNuGet, maxComponents: 4, ignoreCase: true},
+	VersionSchemeDebian: &debianScheme{},
+}
+
+// RegisterVersionScheme registers a version scheme by its name, replacing
+// built-in schemes of the same name
+func RegisterVersionScheme(scheme VersionScheme) {
+	versionSchemes[scheme.GetName()] = scheme
+}
+
+// GetVersionScheme returns the version scheme with the name, the semver
+// scheme if name is empty and nil if the scheme is unknown
+func GetVersionScheme(name string) VersionScheme {
+	if name == "" {
+		name = VersionSchemeSemver
+	}
+
+	return versionSchemes[name]
+}
+
+// getQualifierName returns the name of a qualifier without separators and
+// trailing numbers (e.g. '-beta.2' => 'beta')
+func getQualifierName(qualifier string) string {
+	name := strings.TrimLeft(qualifier, "-.~_")
+	name = strings.TrimRight(name, "0123456789-.~_")
+
+	return strings.ToLower(name)
+}
+
+// parseQualifierName returns the release channel of a common qualifier name
+// or of a defined release channel with the name
+func parseQualifierName(name string, releaseChannels *ReleaseChannels) ReleaseChannel {
+	if releaseChannel := ReleaseChannel(strings.ToUpper(name)); name != "" && releaseChannels.IsDefined(releaseChannel) {
+		return releaseChannel
+	}
+
+	switch name {
+	case "":
+		return ReleaseChannelFinal
+	case "a", "alpha":
+		return ReleaseChannelAlpha
+	case "b", "beta":
+		return ReleaseChannelBeta
+	case "c", "rc", "cr", "gamma", "pre", "preview", "m", "milestone":
+		return ReleaseChannelGamma
+	default:
+		return ReleaseChannelNone
+	}
+}
+
+// getDashQualifier returns the qualifier of the release channel in semver
+// style (e.g. '-beta', '-nightly' for a custom release channel NIGHTLY)
+func getDashQualifier(releaseChannel ReleaseChannel, releaseChannels *ReleaseChannels, noneQualifier string) string {
+	return getQualifier(releaseChannel, releaseChannels, "-", noneQualifier)
+}
+
+// getQualifier returns the qualifier of the release channel with the separator
+func getQualifier(releaseChannel ReleaseChannel, releaseChannels *ReleaseChannels, separator string, noneQualifier string) string {
+	switch releaseChannel {
+	case ReleaseChannelNone:
+		return noneQualifier
+	case ReleaseChannelAlpha:
+		return separator + "alpha"
+	case ReleaseChannelBeta:
+		return separator + "beta"
+	case ReleaseChannelGamma:
+		return separator + "rc"
+	case ReleaseChannelFinal:
+		return ""
+	default:
+		if !releaseChannels.IsDefined(releaseChannel) {
+			return noneQualifier
+		}
+
+		return separator + strings.ToLower(string(releaseChannel))
+	}
+}
+
+type semverScheme struct{}
+
+func (s *semverScheme) GetName() string {
+	return VersionSchemeSemver
+}
+
+func (s *semverScheme) Parse(version string) (SchemeVersion, error) {
+	parsedVersion, err := ParseVersion(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsedVersion, nil
+}
+
+func (s *semverScheme) Compare(a SchemeVersion, b SchemeVersion) int {
+	return a.(*Version).Compare(b.(*Version))
+}
+
+func (s *semverScheme) GetQualifier(releaseChannel ReleaseChannel, releaseChannels *ReleaseChannels) string {
+	return getDashQualifier(releaseChannel, releaseChannels, "-dev")
+}
+
+func (s *semverScheme) GetQualifierPattern() string {
+	return `(?:-[a-zA-Z]+)?`
+}
+
+func (s *semverScheme) ParseQualifier(qualifier string, releaseChannels *ReleaseChannels) ReleaseChannel {
+	return parseQualifierName(getQualifierName(qualifier), releaseChannels)
+}
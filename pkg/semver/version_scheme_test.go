@@ -0,0 +1,139 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func assertSchemeOrder(t *testing.T, schemeName string, versions []string) {
+	scheme := GetVersionScheme(schemeName)
+	assert.NotNil(t, scheme)
+
+	for i := 0; i < len(versions)-1; i++ {
+		a, err := scheme.Parse(versions[i])
+		assert.NoError(t, err)
+
+		b, err := scheme.Parse(versions[i+1])
+		assert.NoError(t, err)
+
+		assert.Equal(t, -1, scheme.Compare(a, b), "%s: %s < %s", schemeName, versions[i], versions[i+1])
+		assert.Equal(t, 1, scheme.Compare(b, a), "%s: %s > %s", schemeName, versions[i+1], versions[i])
+	}
+}
+
+func TestVersionSchemePEP440(t *testing.T) {
+	assertSchemeOrder(t, VersionSchemePEP440, []string{
+		"1.0.dev0",
+		"1.0a1.dev1",
+		"1.0a1",
+		"1.0a3",
+		"1.0b1",
+		"1.0rc1",
+		"1.0",
+		"1.0+local",
+		"1.0.post1.dev2",
+		"1.0.post1",
+		"1.0.1",
+		"1!0.1",
+	})
+
+	scheme := GetVersionScheme(VersionSchemePEP440)
+
+	a, err := scheme.Parse("1.2.0-RC.1")
+	assert.NoError(t, err)
+	b, err := scheme.Parse("1.2rc1")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, scheme.Compare(a, b))
+
+	_, err = scheme.Parse("1.2.0-foo")
+	assert.Error(t, err)
+
+	assert.Equal(t, "a", scheme.GetQualifier(ReleaseChannelAlpha, nil))
+	assert.Equal(t, ".dev", scheme.GetQualifier(ReleaseChannelNone, nil))
+	assert.Equal(t, ReleaseChannelGamma, scheme.ParseQualifier("rc", nil))
+	assert.Equal(t, ReleaseChannelFinal, scheme.ParseQualifier(".post", nil))
+}
+
+func TestVersionSchemeCalVer(t *testing.T) {
+	assertSchemeOrder(t, VersionSchemeCalVer, []string{
+		"2023.12.5",
+		"2024.01.0-beta.2",
+		"2024.01.0-beta.10",
+		"2024.01.0",
+		"2024.1.1",
+		"2024.02",
+		"2024.10.0",
+	})
+}
+
+func TestVersionSchemeNuGet(t *testing.T) {
+	assertSchemeOrder(t, VersionSchemeNuGet, []string{
+		"1.0.0-alpha",
+		"1.0.0-Beta",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.0.1",
+		"1.0.1",
+	})
+
+	scheme := GetVersionScheme(VersionSchemeNuGet)
+
+	a, err := scheme.Parse("1.0.0-BETA")
+	assert.NoError(t, err)
+	b, err := scheme.Parse("1.0-beta+build.5")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, scheme.Compare(a, b))
+
+	_, err = scheme.Parse("1.0.0.0.1")
+	assert.Error(t, err)
+}
+
+func TestVersionSchemeDebian(t *testing.T) {
+	assertSchemeOrder(t, VersionSchemeDebian, []string{
+		"1.0~alpha1",
+		"1.0~rc1",
+		"1.0",
+		"1.0-1",
+		"1.0-1ubuntu1",
+		"1.0-2",
+		"1.0a",
+		"1.0+dfsg-1",
+		"1.2",
+		"1.10",
+		"1:0.9",
+	})
+
+	scheme := GetVersionScheme(VersionSchemeDebian)
+
+	_, err := scheme.Parse("a1.0")
+	assert.Error(t, err)
+
+	assert.Equal(t, "~beta", scheme.GetQualifier(ReleaseChannelBeta, nil))
+	assert.Equal(t, ReleaseChannelBeta, scheme.ParseQualifier("~beta", nil))
+}
+
+func TestVersionSchemeRegister(t *testing.T) {
+	assert.Nil(t, GetVersionScheme("CUSTOM"))
+	assert.Equal(t, VersionSchemeSemver, GetVersionScheme("").GetName())
+
+	RegisterVersionScheme(&numericScheme{name: "CUSTOM"})
+	defer delete(versionSchemes, "CUSTOM")
+
+	assert.Equal(t, "CUSTOM", GetVersionScheme("CUSTOM").GetName())
+}
+
+func TestTagCompareScheme(t *testing.T) {
+	newTag := func(name string, scheme string) *Tag {
+		return NewTag(name, &VersionInfo{Major: 1, Scheme: GetVersionScheme(scheme)}, "")
+	}
+
+	// Maven: rc < SNAPSHOT, semver: SNAPSHOT < rc1 (ASCII order)
+	assert.Equal(t, 1, newTag("1.0.0-SNAPSHOT", VersionSchemeMaven).Compare(newTag("1.0.0-rc1", VersionSchemeMaven)))
+	assert.Equal(t, -1, newTag("1.0.0-SNAPSHOT", VersionSchemeSemver).Compare(newTag("1.0.0-rc1", VersionSchemeSemver)))
+	assert.Equal(t, -1, newTag("v1.0.0.2", VersionSchemeMaven).Compare(newTag("v1.0.0.10", VersionSchemeMaven)))
+	assert.Equal(t, -1, newTag("v1.0.0rc1", VersionSchemePEP440).Compare(newTag("v1.0.0", VersionSchemePEP440)))
+	assert.Equal(t, -1, newTag("pkg/1.0~rc1", VersionSchemeDebian).Compare(newTag("pkg/1.0", VersionSchemeDebian)))
+	assert.NotNil(t, newTag("v1.0.0", VersionSchemeSemver).SemVer)
+	assert.Nil(t, newTag("v1.0.0", VersionSchemeCalVer).SemVer)
+}
@@ -0,0 +1,98 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVersion(t *testing.T) {
+	version, err := ParseVersion("1.2.3-beta.11.x-y+build.5.2021")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, version.Major)
+	assert.Equal(t, 2, version.Minor)
+	assert.Equal(t, 3, version.Patch)
+	assert.Equal(t, []string{"beta", "11", "x-y"}, version.Prerelease)
+	assert.Equal(t, []string{"build", "5", "2021"}, version.Metadata)
+	assert.True(t, version.IsPrerelease())
+	assert.Equal(t, "1.2.3-beta.11.x-y+build.5.2021", version.String())
+
+	version, err = ParseVersion("0.0.4")
+	assert.NoError(t, err)
+	assert.False(t, version.IsPrerelease())
+	assert.Equal(t, "0.0.4", version.String())
+}
+
+func TestParseVersionInvalid(t *testing.T) {
+	for _, str := range []string{"1.2", "01.2.3", "1.2.3-", "1.2.3-01", "1.2.3-beta..1", "1.2.3+", "1.2.3+a_b", "v1.2.3", "1.2.3.4"} {
+		_, err := ParseVersion(str)
+		assert.Error(t, err, str)
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	// Ordering from https://semver.org/spec/v2.0.0.html#spec-item-11
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.1.0",
+		"2.0.0",
+	}
+
+	for i := range ordered {
+		a, err := ParseVersion(ordered[i])
+		assert.NoError(t, err)
+
+		for j := range ordered {
+			b, err := ParseVersion(ordered[j])
+			assert.NoError(t, err)
+
+			switch {
+			case i < j:
+				assert.Equal(t, -1, a.Compare(b), "%s < %s", ordered[i], ordered[j])
+			case i > j:
+				assert.Equal(t, 1, a.Compare(b), "%s > %s", ordered[i], ordered[j])
+			default:
+				assert.Equal(t, 0, a.Compare(b), "%s == %s", ordered[i], ordered[j])
+			}
+		}
+	}
+}
+
+func TestVersionCompareMetadata(t *testing.T) {
+	a, err := ParseVersion("1.0.0-beta.1+build.1")
+	assert.NoError(t, err)
+
+	b, err := ParseVersion("1.0.0-beta.1+build.2")
+	assert.NoError(t, err)
+
+	assert.Equal(t, 0, a.Compare(b))
+}
+
+func TestParseTagVersion(t *testing.T) {
+	assert.Equal(t, "1.2.3-beta.1", ParseTagVersion("v1.2.3-beta.1").String())
+	assert.Equal(t, "2.0.0", ParseTagVersion("api/v2.0.0").String())
+	assert.Nil(t, ParseTagVersion("release-1.2"))
+}
+
+func TestTagCompare(t *testing.T) {
+	a := NewTag("v1.0.0-beta.2", &VersionInfo{Major: 1, Build: 2, ReleaseChannel: ReleaseChannelBeta}, "")
+	b := NewTag("v1.0.0-beta.11", &VersionInfo{Major: 1, Build: 11, ReleaseChannel: ReleaseChannelBeta}, "")
+	c := NewTag("v1.0.0", &VersionInfo{Major: 1, ReleaseChannel: ReleaseChannelFinal}, "")
+
+	assert.Equal(t, -1, a.Compare(b))
+	assert.Equal(t, 1, c.Compare(b))
+
+	// Tags without semantic version are compared by their version info
+	d := NewTag("release-1.1", &VersionInfo{Major: 1, Minor: 1, ReleaseChannel: ReleaseChannelFinal}, "")
+	assert.Nil(t, d.SemVer)
+	assert.Equal(t, 1, d.Compare(c))
+	assert.Equal(t, -1, c.Compare(d))
+}
@@ -1,11 +1,25 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/indece-official/semantic-version/pkg/semver"
+	"github.com/indece-official/semantic-version/pkg/semver/analyzer"
+	"github.com/indece-official/semantic-version/pkg/semver/bump"
+	"github.com/indece-official/semantic-version/pkg/semver/changelog"
+	"github.com/indece-official/semantic-version/pkg/semver/config"
+	"github.com/indece-official/semantic-version/pkg/semver/publish"
+	"github.com/indece-official/semantic-version/pkg/semver/release"
 )
 
 // Variables set during build
@@ -17,6 +31,27 @@ var (
 )
 
 var flagVersion = flag.Bool("v", false, "Print the version info and exit")
+var flagConfigFilename = flag.String("config", config.DefaultFilename, "")
+var flagGitBranch = flag.String("git-branch", "", "")
+var flagBuild = flag.Int("build", -1, "")
+var flagDebug = flag.Bool("debug", false, "Log debug messages (same as -log-level debug)")
+var flagLogLevel = flag.String("log-level", "warn", "Minimum level of logged messages (debug, info, warn, error)")
+var flagLogFormat = flag.String("log-format", string(semver.LogFormatText), "Format of logged messages (text, json)")
+var flagLogFile = flag.String("log-file", "", "File log messages are appended to, default stderr")
+var flagAll = flag.Bool("all", false, "Regenerate the sections of all releases (update-changelog)")
+var flagForce = flag.Bool("force", false, "Replace an existing section (update-changelog)")
+var flagRemote = flag.String("remote", "", "Remote the tag is pushed to (overrides tag.remote from config)")
+var flagFrom = flag.String("from", "", "Revision (tag, branch or commit) the changelog starts after (get-changelog)")
+var flagTo = flag.String("to", "", "Revision (tag, branch or commit) the changelog ends with, default HEAD (get-changelog)")
+var flagProject = flag.String("project", "", "Name of the project (monorepo), get-version and get-changelog output all projects if empty")
+var flagDate = flag.String("date", "", "Date of calendar versions (YYYY-MM-DD), default is the commit date of HEAD")
+var flagChannel = flag.String("channel", "", "Release channel the tag is promoted to, default is the next configured release channel (promote)")
+var flagDryRun = flag.Bool("dry-run", false, "Print the changes as diff without writing the files (bump-files)")
+var flagMaxCommits = flag.Int("max-commits", 0, "Maximum number of commits shown, 0 shows all commits (graph)")
+var flagTemplate = flag.String("template", "", "Changelog template: markdown, keep-a-changelog, text, html or a template file (overrides changelog.template from config)")
+
+// logger receives the diagnostics of all commands, set by setupLogger
+var logger semver.Logger = semver.NopLogger
 
 func printOwnVersion() error {
 	fmt.Printf("%s %s (Build %s)\n", ProjectName, BuildVersion, BuildDate)
@@ -29,114 +64,659 @@ func printOwnVersion() error {
 }
 
 func generateConfig() error {
-	return GenerateConfig()
+	return config.Generate(*flagConfigFilename)
 }
 
-func getVersion() error {
-	config, err := LoadConfig()
+// setupLogger creates the logger selected by -log-level, -log-format and
+// -log-file, the returned function closes the log file
+func setupLogger() (func(), error) {
+	level, err := semver.ParseLogLevel(*flagLogLevel)
 	if err != nil {
-		return fmt.Errorf("error loading config: %s", err)
+		return nil, err
+	}
+
+	if *flagDebug {
+		level = semver.LogLevelDebug
+	}
+
+	format, err := semver.ParseLogFormat(*flagLogFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	output := os.Stderr
+	closeOutput := func() {}
+
+	if *flagLogFile != "" {
+		output, err = os.OpenFile(*flagLogFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("can't open log file %s: %s", *flagLogFile, err)
+		}
+
+		closeOutput = func() {
+			output.Close()
+		}
 	}
 
-	analyzer := NewAnalyzer(config)
+	logger = semver.NewWriterLogger(output, level, format)
+	semver.SetDefaultLogger(logger)
+
+	return closeOutput, nil
+}
+
+func loadConfigAndRepository() (*config.Config, *git.Repository, error) {
+	cfg, err := config.LoadWithLogger(*flagConfigFilename, logger)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading config: %s", err)
+	}
+
+	if *flagTemplate != "" {
+		// Copy the changelog config, so the default config stays untouched
+		changelogConfig := *cfg.Changelog
+		changelogConfig.Template = *flagTemplate
+		cfg.Changelog = &changelogConfig
+	}
 
 	repo, err := git.PlainOpen(".")
 	if err != nil {
-		return fmt.Errorf("error opening repository: %s", err)
+		return nil, nil, fmt.Errorf("error opening repository: %s", err)
+	}
+
+	return cfg, repo, nil
+}
+
+// getProjects returns the names of the projects selected by -project (all
+// configured projects if not set), a single empty name if no projects are
+// configured
+func getProjects(cfg *config.Config) []string {
+	if *flagProject != "" || len(cfg.Projects) == 0 {
+		return []string{*flagProject}
+	}
+
+	projects := []string{}
+	for _, project := range cfg.Projects {
+		projects = append(projects, project.Name)
+	}
+
+	return projects
+}
+
+// getProject returns the name of the project selected by -project, which is
+// required if projects are configured
+func getProject(cfg *config.Config) (string, error) {
+	if *flagProject == "" && len(cfg.Projects) > 0 {
+		return "", fmt.Errorf("-project is required if projects are configured")
+	}
+
+	return *flagProject, nil
+}
+
+// getDate returns the date of calendar versions selected by -date, nil if not
+// set
+func getDate() (*time.Time, error) {
+	if *flagDate == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse("2006-01-02", *flagDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %s: %s", *flagDate, err)
+	}
+
+	return &date, nil
+}
+
+func newAnalyzer(cfg *config.Config, repo *git.Repository, project string) (*analyzer.Analyzer, error) {
+	options := &analyzer.Options{
+		Branch:  *flagGitBranch,
+		Project: project,
+		Logger:  logger,
+	}
+
+	if *flagBuild >= 0 {
+		options.Build = flagBuild
+	}
+
+	date, err := getDate()
+	if err != nil {
+		return nil, err
 	}
 
-	err = analyzer.Load(repo)
+	options.Date = date
+
+	a := analyzer.NewAnalyzer(repo, cfg, options)
+
+	err = a.Load()
 	if err != nil {
-		return fmt.Errorf("error loading analyzer: %s", err)
+		return nil, fmt.Errorf("error loading analyzer: %s", err)
 	}
 
-	branchName, branchConfig, err := analyzer.GetCurrentBranchConfig(repo)
+	return a, nil
+}
+
+func getVersion() error {
+	cfg, repo, err := loadConfigAndRepository()
 	if err != nil {
-		return fmt.Errorf("error getting branch config: %s", err)
+		return err
 	}
 
-	if branchConfig == nil {
-		fmt.Printf("UNKNOWN\n")
+	projects := getProjects(cfg)
+	results := []*analyzer.VersionResult{}
+
+	for _, project := range projects {
+		a, err := newAnalyzer(cfg, repo, project)
+		if err != nil {
+			return err
+		}
+
+		result, err := a.ComputeVersion()
+		if err != nil {
+			if !errors.Is(err, analyzer.ErrNoBranchConfig) {
+				return err
+			}
+
+			result = &analyzer.VersionResult{
+				Project:   project,
+				Version:   "UNKNOWN",
+				Increment: semver.VersionIncrementLevelNone,
+				Reasons:   []*analyzer.VersionResultReason{},
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	// Output version
+	if *flagProject == "" && len(cfg.Projects) > 0 {
+		return printVersionResults(results)
+	}
+
+	return printVersionResult(results[0])
+}
+
+func getProjectChangelog(a *analyzer.Analyzer) (string, error) {
+	if *flagFrom != "" || *flagTo != "" {
+		to := *flagTo
+		if to == "" {
+			to = "HEAD"
+		}
+
+		return a.GetChangelogBetween(*flagFrom, to)
+	}
+
+	changelog, err := a.GetChangelog()
+	if err != nil {
+		if errors.Is(err, analyzer.ErrNoBranchConfig) {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	return changelog, nil
+}
+
+func getChangelog() error {
+	cfg, repo, err := loadConfigAndRepository()
+	if err != nil {
+		return err
+	}
+
+	projects := getProjects(cfg)
+
+	for _, project := range projects {
+		a, err := newAnalyzer(cfg, repo, project)
+		if err != nil {
+			return err
+		}
+
+		changelog, err := getProjectChangelog(a)
+		if err != nil {
+			return err
+		}
+
+		if *flagProject == "" && len(cfg.Projects) > 0 {
+			fmt.Printf("# %s\n\n", project)
+		}
+
+		fmt.Printf("%s\n", changelog)
+	}
+
+	return nil
+}
+
+func newTagger(cfg *config.Config, repo *git.Repository) *release.Tagger {
+	options := &release.TaggerOptions{
+		Remote:               *flagRemote,
+		SigningKeyPassphrase: os.Getenv("SEMVER_SIGNING_KEY_PASSPHRASE"),
+		Logger:               logger,
+	}
+
+	if os.Getenv("SEMVER_GIT_USERNAME") != "" || os.Getenv("SEMVER_GIT_PASSWORD") != "" {
+		options.Auth = &http.BasicAuth{
+			Username: os.Getenv("SEMVER_GIT_USERNAME"),
+			Password: os.Getenv("SEMVER_GIT_PASSWORD"),
+		}
+	}
+
+	return release.NewTagger(repo, cfg.Tag, options)
+}
+
+func tagVersion() error {
+	cfg, repo, err := loadConfigAndRepository()
+	if err != nil {
+		return err
+	}
+
+	project, err := getProject(cfg)
+	if err != nil {
+		return err
+	}
+
+	a, err := newAnalyzer(cfg, repo, project)
+	if err != nil {
+		return err
+	}
+
+	result, err := a.ComputeVersion()
+	if err != nil {
+		return err
+	}
+
+	if result.KeepsPreviousVersion() {
+		// Only changes without version increment, nothing to tag
+		fmt.Printf("%s\n", result.Version)
 
 		return nil
 	}
 
-	highestVersion, err := analyzer.GetHighestFinalReleaseVersion(repo)
+	changes, err := a.GetChangelog()
 	if err != nil {
-		return fmt.Errorf("error getting highest final release: %s", err)
+		return err
+	}
+
+	message := changes
+	if strings.TrimSpace(message) == "" {
+		message = fmt.Sprintf("Release %s", result.Version)
 	}
 
-	commits, err := analyzer.GetCommitsSinceLastRelease(repo, branchConfig, ReleaseChannelFinal)
+	tagger := newTagger(cfg, repo)
+
+	newTag, err := tagger.Release(
+		result.Version,
+		plumbing.NewHash(result.Commit),
+		message,
+		func(usedTags map[string]bool) (string, error) {
+			err := a.RegenerateVersion(result, usedTags)
+			if err != nil {
+				return "", err
+			}
+
+			return result.Version, nil
+		},
+	)
 	if err != nil {
-		return fmt.Errorf("error loading commits since last release: %s", err)
+		return fmt.Errorf("error creating tag: %s", err)
 	}
 
-	commitParser := NewCommitParser()
-	commitParser.Parse(commits)
+	fmt.Printf("%s\n", newTag)
 
-	if highestVersion != nil {
-		versionIncrement := commitParser.GetVersionIncrement()
-		versionIncrement.Apply(highestVersion)
-	} else {
-		highestVersion = &VersionInfo{
-			Major: 1,
-			Minor: 0,
-			Patch: 0,
-			Build: 0,
+	return nil
+}
+
+func publishRelease() error {
+	cfg, repo, err := loadConfigAndRepository()
+	if err != nil {
+		return err
+	}
+
+	if cfg.Publish == nil {
+		return fmt.Errorf("no publish config in %s", *flagConfigFilename)
+	}
+
+	project, err := getProject(cfg)
+	if err != nil {
+		return err
+	}
+
+	a, err := newAnalyzer(cfg, repo, project)
+	if err != nil {
+		return err
+	}
+
+	publication, err := a.GetPublication()
+	if err != nil {
+		if errors.Is(err, analyzer.ErrNoVersionTag) {
+			return fmt.Errorf("%s, create it with the tag command first", err)
 		}
+
+		return err
 	}
 
-	newTag, err := analyzer.GeneraterVersionTag(branchName, branchConfig, highestVersion)
+	assets, err := publish.FindAssets(cfg.Publish.Assets)
 	if err != nil {
-		return fmt.Errorf("error generating version: %s", err)
+		return err
+	}
+
+	provider, err := publish.NewProvider(cfg.Publish, &publish.ProviderOptions{
+		Token: os.Getenv("SEMVER_PUBLISH_TOKEN"),
+	})
+	if err != nil {
+		return err
+	}
+
+	publisher := publish.NewPublisher(provider)
+	publisher.SetLogger(logger)
+
+	publishedRelease, err := publisher.Publish(&publish.Release{
+		Tag:        publication.Tag,
+		Name:       publication.Tag,
+		Body:       publication.Changelog,
+		Prerelease: publication.Prerelease,
+	}, assets)
+	if err != nil {
+		return fmt.Errorf("error publishing release: %s", err)
+	}
+
+	fmt.Printf("%s\n", publishedRelease.URL)
+
+	return nil
+}
+
+func promote() error {
+	if len(flag.Args()) != 2 {
+		return fmt.Errorf("usage: semantic-version [args] promote <tag>")
+	}
+
+	cfg, repo, err := loadConfigAndRepository()
+	if err != nil {
+		return err
+	}
+
+	project, err := getProject(cfg)
+	if err != nil {
+		return err
+	}
+
+	a, err := newAnalyzer(cfg, repo, project)
+	if err != nil {
+		return err
+	}
+
+	promotion, err := a.Promote(flag.Arg(1), semver.ReleaseChannel(strings.ToUpper(*flagChannel)))
+	if err != nil {
+		return err
+	}
+
+	tagger := newTagger(cfg, repo)
+
+	newTag, err := tagger.Release(
+		promotion.Version,
+		plumbing.NewHash(promotion.Commit),
+		fmt.Sprintf("Release %s (promoted from %s)", promotion.Version, promotion.From),
+		func(usedTags map[string]bool) (string, error) {
+			err := a.RegeneratePromotion(promotion, usedTags)
+			if err != nil {
+				return "", err
+			}
+
+			return promotion.Version, nil
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error creating tag: %s", err)
 	}
 
-	// Output version
 	fmt.Printf("%s\n", newTag)
 
 	return nil
 }
 
-func getChangelog() error {
-	config, err := LoadConfig()
+func explain() error {
+	cfg, repo, err := loadConfigAndRepository()
 	if err != nil {
-		return fmt.Errorf("error loading config: %s", err)
+		return err
 	}
 
-	analyzer := NewAnalyzer(config)
+	project, err := getProject(cfg)
+	if err != nil {
+		return err
+	}
 
-	repo, err := git.PlainOpen(".")
+	a, err := newAnalyzer(cfg, repo, project)
+	if err != nil {
+		return err
+	}
+
+	explanation, err := a.Explain()
+	if err != nil {
+		return err
+	}
+
+	return printExplanation(explanation)
+}
+
+func listIssues() error {
+	cfg, repo, err := loadConfigAndRepository()
+	if err != nil {
+		return err
+	}
+
+	project, err := getProject(cfg)
+	if err != nil {
+		return err
+	}
+
+	a, err := newAnalyzer(cfg, repo, project)
+	if err != nil {
+		return err
+	}
+
+	issues, err := a.GetIssues()
+	if err != nil {
+		return err
+	}
+
+	return printIssues(issues)
+}
+
+func drawGraph() error {
+	cfg, repo, err := loadConfigAndRepository()
 	if err != nil {
-		return fmt.Errorf("error opening repository: %s", err)
+		return err
 	}
 
-	err = analyzer.Load(repo)
+	project, err := getProject(cfg)
 	if err != nil {
-		return fmt.Errorf("error loading analyzer: %s", err)
+		return err
 	}
 
-	_, branchConfig, err := analyzer.GetCurrentBranchConfig(repo)
+	a, err := newAnalyzer(cfg, repo, project)
 	if err != nil {
-		return fmt.Errorf("error getting branch config: %s", err)
+		return err
 	}
 
-	if branchConfig == nil {
-		fmt.Printf("\n")
+	history, err := a.GetGraph(*flagMaxCommits)
+	if err != nil {
+		return err
+	}
+
+	return printGraph(history)
+}
+
+func printReleasePlan() error {
+	cfg, repo, err := loadConfigAndRepository()
+	if err != nil {
+		return err
+	}
+
+	if len(cfg.Projects) == 0 {
+		return fmt.Errorf("no projects configured")
+	}
+
+	options := &analyzer.Options{
+		Branch: *flagGitBranch,
+		Logger: logger,
+	}
+
+	if *flagBuild >= 0 {
+		options.Build = flagBuild
+	}
+
+	options.Date, err = getDate()
+	if err != nil {
+		return err
+	}
+
+	plan, err := analyzer.GetReleasePlan(repo, cfg, options)
+	if err != nil {
+		return err
+	}
+
+	if OutputFormat(*flagOutput) != OutputFormatText {
+		return printVersionResults(plan)
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	step := 0
+
+	for _, result := range plan {
+		if !result.HasChanges() {
+			fmt.Fprintf(writer, "-\t%s\t%s\tunchanged\n", result.Project, result.PreviousVersion)
+
+			continue
+		}
+
+		step++
+
+		dependencies := []string{}
+		for _, dependency := range result.Dependencies {
+			dependencies = append(dependencies, dependency.Project)
+		}
+
+		reason := result.Increment.String()
+		if len(dependencies) > 0 {
+			reason += fmt.Sprintf(" (dependencies: %s)", strings.Join(dependencies, ", "))
+		}
+
+		fmt.Fprintf(writer, "%d.\t%s\t%s\t%s\n", step, result.Project, result.Version, reason)
+	}
+
+	return writer.Flush()
+}
+
+func updateChangelog() error {
+	cfg, repo, err := loadConfigAndRepository()
+	if err != nil {
+		return err
+	}
+
+	project, err := getProject(cfg)
+	if err != nil {
+		return err
+	}
+
+	a, err := newAnalyzer(cfg, repo, project)
+	if err != nil {
+		return err
+	}
+
+	changelogFilename := cfg.GetChangelogFile(a.GetProject())
+
+	content, err := ioutil.ReadFile(changelogFilename)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("can't read changelog file %s: %s", changelogFilename, err)
+	}
+
+	changelogFile := changelog.ParseChangelogFile(string(content))
+
+	section, err := a.GetChangelogSection(time.Now())
+	if err != nil {
+		return err
+	}
+
+	if *flagAll {
+		sections, err := a.GetChangelogHistory()
+		if err != nil {
+			return err
+		}
+
+		if len(sections) == 0 || sections[0].Version != section.Version {
+			sections = append([]*changelog.ChangelogSection{section}, sections...)
+		}
+
+		changelogFile.ReplaceSections(sections)
+	} else {
+		err = changelogFile.AddSection(section, *flagForce)
+	}
+	if err != nil {
+		if !errors.Is(err, changelog.ErrSectionExists) {
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "Changelog %s already contains section for %s\n", changelogFilename, section.Version)
 
 		return nil
 	}
 
-	commits, err := analyzer.GetCommitsSinceLastRelease(repo, branchConfig, ReleaseChannelAlpha)
+	err = ioutil.WriteFile(changelogFilename, []byte(changelogFile.String()), 0644)
 	if err != nil {
-		return fmt.Errorf("error loading commits since last release: %s", err)
+		return fmt.Errorf("can't write changelog file %s: %s", changelogFilename, err)
 	}
 
-	commitParser := NewCommitParser()
-	commitParser.Parse(commits)
+	fmt.Printf("%s\n", section.Version)
 
-	changelog := commitParser.GenerateChangelog()
+	return nil
+}
+
+func bumpFiles() error {
+	cfg, repo, err := loadConfigAndRepository()
+	if err != nil {
+		return err
+	}
+
+	project, err := getProject(cfg)
+	if err != nil {
+		return err
+	}
+
+	a, err := newAnalyzer(cfg, repo, project)
+	if err != nil {
+		return err
+	}
+
+	files, baseDir := cfg.GetBumpFiles(a.GetProject())
+	if len(files) == 0 {
+		return fmt.Errorf("no bump files configured")
+	}
+
+	result, err := a.ComputeVersion()
+	if err != nil {
+		return err
+	}
+
+	bumper := bump.NewBumper(files, baseDir)
+	bumper.SetLogger(logger)
+
+	changes, err := bumper.Bump(result.Version, result.GetVersionInfo(), *flagDryRun)
+	if err != nil {
+		return err
+	}
 
-	fmt.Printf("%s\n", changelog)
+	for _, change := range changes {
+		if !*flagDryRun {
+			fmt.Printf("%s: %s\n", change.Filename, change.Version)
+
+			continue
+		}
+
+		diff, err := change.Diff()
+		if err != nil {
+			return fmt.Errorf("error generating diff of %s: %s", change.Filename, err)
+		}
+
+		fmt.Printf("%s", diff)
+	}
 
 	return nil
 }
@@ -151,6 +731,15 @@ func printHelp() {
 	fmt.Printf("  generate-config  Generate config file 'semanticversion.yaml'\n")
 	fmt.Printf("  get-version      Get the new release version\n")
 	fmt.Printf("  get-changelog    Get a changelog with all changes since the last release\n")
+	fmt.Printf("  explain          Explain how the new release version is computed (branch config, base tag, commits)\n")
+	fmt.Printf("  list-issues      List all issues referenced by the changes since the last release\n")
+	fmt.Printf("  graph            Draw the history with version tags and the new release version of each strategy\n")
+	fmt.Printf("  tag              Create the tag for the new release version and push it to the configured remote\n")
+	fmt.Printf("  update-changelog Add a section for the new release version to the changelog file\n")
+	fmt.Printf("  release-plan     Print the new versions of all projects in release order (dependencies first)\n")
+	fmt.Printf("  bump-files       Write the new release version to the configured files (e.g. package.json, pom.xml)\n")
+	fmt.Printf("  publish-release  Create or update the release of the version tag on HEAD on the configured hosting service\n")
+	fmt.Printf("  promote <tag>    Tag the commit of a prerelease tag with the version of the next release channel\n")
 	fmt.Printf("\n")
 }
 
@@ -168,7 +757,7 @@ func main() {
 		return
 	}
 
-	if len(flag.Args()) != 1 {
+	if len(flag.Args()) != 1 && (len(flag.Args()) != 2 || flag.Arg(0) != "promote") {
 		printHelp()
 
 		os.Exit(1)
@@ -176,6 +765,15 @@ func main() {
 		return
 	}
 
+	closeLogger, err := setupLogger()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %s\n", err)
+
+		os.Exit(1)
+
+		return
+	}
+
 	command := flag.Arg(0)
 
 	switch command {
@@ -185,10 +783,30 @@ func main() {
 		err = getVersion()
 	case "get-changelog":
 		err = getChangelog()
+	case "explain":
+		err = explain()
+	case "list-issues":
+		err = listIssues()
+	case "graph":
+		err = drawGraph()
+	case "tag":
+		err = tagVersion()
+	case "update-changelog":
+		err = updateChangelog()
+	case "release-plan":
+		err = printReleasePlan()
+	case "bump-files":
+		err = bumpFiles()
+	case "publish-release":
+		err = publishRelease()
+	case "promote":
+		err = promote()
 	default:
 		printHelp()
 	}
 
+	closeLogger()
+
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "ERROR: %s\n", err)
 
@@ -0,0 +1,319 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/indece-official/semantic-version/pkg/semver"
+	"github.com/indece-official/semantic-version/pkg/semver/analyzer"
+	"github.com/indece-official/semantic-version/pkg/semver/graph"
+	"gopkg.in/yaml.v3"
+)
+
+type OutputFormat string
+
+const (
+	OutputFormatText OutputFormat = "text"
+	OutputFormatJSON OutputFormat = "json"
+	OutputFormatYAML OutputFormat = "yaml"
+	OutputFormatEnv  OutputFormat = "env"
+	// OutputFormatDOT is the Graphviz DOT output of graph
+	OutputFormatDOT OutputFormat = "dot"
+	// OutputFormatMermaid is the Mermaid output of graph
+	OutputFormatMermaid OutputFormat = "mermaid"
+)
+
+var flagOutput = flag.String("output", string(OutputFormatText), "Output format of get-version (text, json, yaml, env) explain (text, json, yaml) graph (text, dot, mermaid) and list-issues (text, json, yaml)")
+
+// quoteEnv quotes a value for usage in a shell environment file
+func quoteEnv(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "'\\''") + "'"
+}
+
+var expEnvNameInvalidChars = regexp.MustCompile(`[^A-Z0-9_]`)
+
+// formatEnvPrefix returns the prefix of the variables of a project (e.g.
+// 'SEMVER_MY_API_' for the project 'my-api')
+func formatEnvPrefix(project string) string {
+	if project == "" {
+		return "SEMVER_"
+	}
+
+	return "SEMVER_" + expEnvNameInvalidChars.ReplaceAllString(strings.ToUpper(project), "_") + "_"
+}
+
+func formatEnv(result *analyzer.VersionResult, prefix string) string {
+	reasonHashes := []string{}
+	for _, reason := range result.Reasons {
+		reasonHashes = append(reasonHashes, reason.Hash)
+	}
+
+	vars := [][]string{
+		{"VERSION", result.Version},
+		{"PREVIOUS_VERSION", result.PreviousVersion},
+		{"INCREMENT", result.Increment.String()},
+		{"REASONS", strings.Join(reasonHashes, " ")},
+		{"BRANCH", result.Branch},
+		{"BRANCH_PATTERN", result.BranchPattern},
+		{"VERSION_PATTERN", result.VersionPattern},
+		{"RELEASE_CHANNEL", string(result.ReleaseChannel)},
+		{"MAJOR", fmt.Sprintf("%d", result.Major)},
+		{"MINOR", fmt.Sprintf("%d", result.Minor)},
+		{"PATCH", fmt.Sprintf("%d", result.Patch)},
+		{"BUILD", fmt.Sprintf("%d", result.Build)},
+		{"COMMIT", result.Commit},
+		{"SHORT_COMMIT", result.ShortCommit},
+	}
+
+	str := ""
+	for _, v := range vars {
+		str += fmt.Sprintf("%s%s=%s\n", prefix, v[0], quoteEnv(v[1]))
+	}
+
+	return str
+}
+
+func printVersionResult(result *analyzer.VersionResult) error {
+	switch OutputFormat(*flagOutput) {
+	case OutputFormatText:
+		fmt.Printf("%s\n", result.Version)
+	case OutputFormatJSON:
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error encoding result: %s", err)
+		}
+
+		fmt.Printf("%s\n", data)
+	case OutputFormatYAML:
+		data, err := yaml.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("error encoding result: %s", err)
+		}
+
+		fmt.Printf("%s", data)
+	case OutputFormatEnv:
+		fmt.Printf("%s", formatEnv(result, formatEnvPrefix("")))
+	default:
+		return fmt.Errorf("invalid output format \"%s\"", *flagOutput)
+	}
+
+	return nil
+}
+
+// printVersionResults prints the results of multiple projects
+func printVersionResults(results []*analyzer.VersionResult) error {
+	switch OutputFormat(*flagOutput) {
+	case OutputFormatText:
+		for _, result := range results {
+			fmt.Printf("%s %s\n", result.Project, result.Version)
+		}
+	case OutputFormatJSON:
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error encoding results: %s", err)
+		}
+
+		fmt.Printf("%s\n", data)
+	case OutputFormatYAML:
+		data, err := yaml.Marshal(results)
+		if err != nil {
+			return fmt.Errorf("error encoding results: %s", err)
+		}
+
+		fmt.Printf("%s", data)
+	case OutputFormatEnv:
+		for _, result := range results {
+			fmt.Printf("%s", formatEnv(result, formatEnvPrefix(result.Project)))
+		}
+	default:
+		return fmt.Errorf("invalid output format \"%s\"", *flagOutput)
+	}
+
+	return nil
+}
+
+// shortHash abbreviates a commit hash
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+
+	return hash
+}
+
+// formatExplanation formats an explanation as human readable report
+func formatExplanation(explanation *analyzer.Explanation) string {
+	str := ""
+
+	if explanation.Project != "" {
+		str += fmt.Sprintf("Project:       %s\n", explanation.Project)
+	}
+
+	branchSource := "from HEAD"
+	if explanation.BranchFromOption {
+		branchSource = "from -git-branch"
+	}
+
+	str += fmt.Sprintf("Branch:        %s (%s)\n", explanation.Branch, branchSource)
+
+	branchConfig := explanation.GetBranchConfig()
+	if branchConfig == nil {
+		str += "Branch config: none matches\n"
+	} else {
+		str += fmt.Sprintf("Branch config: %s (release channel %s)\n", branchConfig.BranchPattern, formatReleaseChannel(branchConfig.ReleaseChannel))
+	}
+
+	for _, checkedBranchConfig := range explanation.BranchConfigs {
+		match := "no match"
+		if checkedBranchConfig.Matches {
+			match = "match"
+		}
+
+		str += fmt.Sprintf("  %-20s %s\n", checkedBranchConfig.BranchPattern, match)
+	}
+
+	if explanation.Result == nil {
+		return str
+	}
+
+	str += fmt.Sprintf("Strategy:      %s\n", explanation.Strategy)
+
+	if explanation.BaseTag == "" {
+		str += "Base tag:      none (first release)\n"
+	} else {
+		str += fmt.Sprintf("Base tag:      %s (%s)\n", explanation.BaseTag, shortHash(explanation.BaseTagCommit))
+
+		path := []string{}
+		for _, hash := range explanation.BaseTagPath {
+			path = append(path, shortHash(hash))
+		}
+
+		str += fmt.Sprintf("Path:          HEAD %s\n", strings.Join(path, " -> "))
+	}
+
+	str += fmt.Sprintf("Commits:       %d since base tag\n", len(explanation.Commits))
+
+	for _, commit := range explanation.Commits {
+		commitType := commit.CommitType
+		if commitType == "" {
+			commitType = "(unknown)"
+		}
+
+		if commit.Breaking {
+			commitType += "!"
+		}
+
+		reason := ""
+		if commit.Reason {
+			reason = " *"
+		}
+
+		str += fmt.Sprintf("  %s %-10s %-5s %s%s\n", shortHash(commit.Hash), commitType, commit.Increment, commit.Message, reason)
+	}
+
+	for _, dependency := range explanation.Result.Dependencies {
+		str += fmt.Sprintf("Dependency:    %s %s (%s)\n", dependency.Project, dependency.Version, dependency.Increment)
+	}
+
+	str += fmt.Sprintf("Increment:     %s (* marks the causing commits)\n", explanation.Result.Increment)
+	str += fmt.Sprintf("Version:       %s\n", explanation.Result.Version)
+
+	return str
+}
+
+// formatReleaseChannel returns the name of a release channel, 'none' for
+// branches without release channel
+func formatReleaseChannel(releaseChannel semver.ReleaseChannel) string {
+	if releaseChannel == semver.ReleaseChannelNone {
+		return "none"
+	}
+
+	return string(releaseChannel)
+}
+
+// printExplanation prints the explanation of a version computation
+func printExplanation(explanation *analyzer.Explanation) error {
+	switch OutputFormat(*flagOutput) {
+	case OutputFormatText:
+		fmt.Printf("%s", formatExplanation(explanation))
+	case OutputFormatJSON:
+		data, err := json.MarshalIndent(explanation, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error encoding explanation: %s", err)
+		}
+
+		fmt.Printf("%s\n", data)
+	case OutputFormatYAML:
+		data, err := yaml.Marshal(explanation)
+		if err != nil {
+			return fmt.Errorf("error encoding explanation: %s", err)
+		}
+
+		fmt.Printf("%s", data)
+	default:
+		return fmt.Errorf("invalid output format \"%s\" for explain", *flagOutput)
+	}
+
+	return nil
+}
+
+func printGraph(history *graph.Graph) error {
+	switch OutputFormat(*flagOutput) {
+	case OutputFormatText:
+		fmt.Printf("%s", history.RenderASCII())
+	case OutputFormatDOT:
+		fmt.Printf("%s", history.RenderDOT())
+	case OutputFormatMermaid:
+		fmt.Printf("%s", history.RenderMermaid())
+	default:
+		return fmt.Errorf("invalid output format \"%s\" for graph", *flagOutput)
+	}
+
+	return nil
+}
+
+func printIssues(issues []*analyzer.Issue) error {
+	switch OutputFormat(*flagOutput) {
+	case OutputFormatText:
+		writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+
+		for _, issue := range issues {
+			messages := []string{}
+			for _, commit := range issue.Commits {
+				messages = append(messages, fmt.Sprintf("%s (%s)", commit.Message, shortHash(commit.Hash)))
+			}
+
+			url := issue.URL
+			if url == "" {
+				url = "-"
+			}
+
+			fmt.Fprintf(writer, "%s\t%s\t%s\n", issue.Issue, url, strings.Join(messages, "; "))
+		}
+
+		writer.Flush()
+	case OutputFormatJSON:
+		data, err := json.MarshalIndent(issues, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error encoding issues: %s", err)
+		}
+
+		fmt.Printf("%s\n", data)
+	case OutputFormatYAML:
+		data, err := yaml.Marshal(issues)
+		if err != nil {
+			return fmt.Errorf("error encoding issues: %s", err)
+		}
+
+		fmt.Printf("%s", data)
+	default:
+		return fmt.Errorf("invalid output format \"%s\" for list-issues", *flagOutput)
+	}
+
+	return nil
+}